@@ -2,32 +2,119 @@ package environment
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 )
 
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &environmentDataSource{}
+	_ datasource.DataSourceWithConfigure        = &environmentDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &environmentDataSource{}
+)
+
+type environmentDataSource struct {
+	datasource.DataSourceWithConfigure
+}
+
+func (*environmentDataSource) ConfigValidators(context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		// Validate only one of the schema defined attributes named id and name
+		// has a known, non-null value.
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func NewEnvironmentDataSource() datasource.DataSource {
-	return bzdatasource.NewSingleDataSource(
-		&bzdatasource.SingleDataSourceConfig[environmentModel, environments.Environment]{
-			BaseSingleDataSourceConfig: &bzdatasource.BaseSingleDataSourceConfig[environmentModel, environments.Environment]{
-				RecordSchema:        internal.ResourceSchemaToDataSourceSchema(makeEnvironmentResourceSchema(), bastionzero.PtrTo("id")),
-				MetadataTypeName:    "environment",
-				PrettyAttributeName: "environment",
-				FlattenAPIModel: func(ctx context.Context, apiObject *environments.Environment, state *environmentModel) (diags diag.Diagnostics) {
-					setEnvironmentAttributes(ctx, state, apiObject)
-					return
-				},
-				GetAPIModel: func(ctx context.Context, tfModel environmentModel, client *bastionzero.Client) (*environments.Environment, error) {
-					env, _, err := client.Environments.GetEnvironment(ctx, tfModel.ID.ValueString())
-					return env, err
+	baseDesc := "Get information on a BastionZero environment. An environment is a collection of targets."
+	return &environmentDataSource{
+		DataSourceWithConfigure: bzdatasource.NewSingleDataSourceWithTimeout(
+			&bzdatasource.SingleDataSourceWithTimeoutConfig[environmentModel, environments.Environment]{
+				BaseSingleDataSourceConfig: &bzdatasource.BaseSingleDataSourceConfig[environmentModel, environments.Environment]{
+					RecordSchema:        makeEnvironmentDataSourceSchema(),
+					MetadataTypeName:    "environment",
+					PrettyAttributeName: "environment",
+					FlattenAPIModel: func(ctx context.Context, apiObject *environments.Environment, state *environmentModel) (diags diag.Diagnostics) {
+						setEnvironmentAttributes(ctx, state, apiObject)
+						return
+					},
+					GetAPIModel: func(ctx context.Context, tfModel environmentModel, client *bastionzero.Client) (*environments.Environment, error) {
+						if !tfModel.ID.IsNull() {
+							// ID provided. Use GET API for single environment
+							// with ID.
+							env, _, err := client.Environments.GetEnvironment(ctx, tfModel.ID.ValueString())
+							return env, err
+						} else if !tfModel.Name.IsNull() {
+							// Name provided. List environments and find
+							// environment with specified name.
+							envs, err := listcache.List(ctx, listcache.KindEnvironment, "", func(ctx context.Context) ([]environments.Environment, error) {
+								envs, _, err := client.Environments.ListEnvironments(ctx)
+								return envs, err
+							})
+							if err != nil {
+								return nil, err
+							}
+
+							return findEnvironmentByName(envs, tfModel.Name.ValueString())
+						}
+
+						// This should never happen due to
+						// ConfigValidator.ExactlyOneOf
+						panic("Expected one of \"id\" or \"name\" to be set. Please report this issue to the provider developers.")
+					},
+					Description: baseDesc,
+					MarkdownDescription: baseDesc +
+						"\n\nSpecify exactly one of `id` or `name`. When specifying a `name`, an error is triggered if more than one environment is found. " +
+						"This data source retries with exponential backoff (provide optional `timeouts.read` [duration](https://pkg.go.dev/time#ParseDuration) to control how long to retry. Defaults to 1 minute.) until the environment is found. " +
+						"Retrying is useful if the environment is created outside of this configuration and may not exist yet.",
 				},
-				MarkdownDescription: "Get information on a BastionZero environment. An environment is a collection of targets.",
+				DefaultTimeout: 1 * time.Minute,
 			},
-		},
-	)
+		),
+	}
+}
+
+// makeEnvironmentDataSourceSchema returns the environment data source schema,
+// in which exactly one of id or name is configured and every other attribute
+// is computed.
+func makeEnvironmentDataSourceSchema() map[string]schema.Attribute {
+	dataSourceSchema := internal.ResourceSchemaToDataSourceSchema(makeEnvironmentResourceSchema(), nil)
+	for _, name := range []string{"id", "name"} {
+		attr := dataSourceSchema[name].(schema.StringAttribute)
+		attr.Optional = true
+		dataSourceSchema[name] = attr
+	}
+
+	return dataSourceSchema
+}
+
+func findEnvironmentByName(envList []environments.Environment, name string) (*environments.Environment, error) {
+	results := make([]environments.Environment, 0)
+	for _, env := range envList {
+		if env.Name == name {
+			results = append(results, env)
+		}
+	}
+	if len(results) == 1 {
+		return &results[0], nil
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("No environment found with name %s", name)
+	}
+	return nil, &backoff.PermanentError{Err: fmt.Errorf("Too many environments found with name %s (found %d, expected 1)", name, len(results))}
 }
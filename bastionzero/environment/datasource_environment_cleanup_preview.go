@@ -0,0 +1,236 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// environmentCleanupPreviewModel maps the environment cleanup preview schema
+// data.
+type environmentCleanupPreviewModel struct {
+	EnvironmentID              types.String `tfsdk:"environment_id"`
+	OfflineCleanupTimeoutHours types.Int64  `tfsdk:"offline_cleanup_timeout_hours"`
+	OfflineTargets             types.List   `tfsdk:"offline_targets"` // value is offlineTargetModel
+}
+
+// offlineTargetModel maps offline target data.
+type offlineTargetModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	LastAgentUpdate types.String `tfsdk:"last_agent_update"`
+	CleanupTime     types.String `tfsdk:"cleanup_time"`
+}
+
+func getOfflineTargetModelType(ctx context.Context) types.ObjectType {
+	attributeTypes, _ := internal.AttributeTypes[offlineTargetModel](ctx)
+	return types.ObjectType{AttrTypes: attributeTypes}
+}
+
+// cleanupPreview is an environment's offline targets and the time BastionZero
+// removes each of them.
+type cleanupPreview struct {
+	OfflineCleanupTimeoutHours uint
+	OfflineTargets             []offlineTarget
+}
+
+// offlineTarget is a target that is offline and will be removed by BastionZero
+// at CleanupTime, if CleanupTime is not nil.
+type offlineTarget struct {
+	ID              string
+	Name            string
+	Type            targettype.TargetType
+	LastAgentUpdate *time.Time
+	CleanupTime     *time.Time
+}
+
+func NewEnvironmentCleanupPreviewDataSource() datasource.DataSource {
+	return bzdatasource.NewSingleDataSource(
+		&bzdatasource.SingleDataSourceConfig[environmentCleanupPreviewModel, cleanupPreview]{
+			BaseSingleDataSourceConfig: &bzdatasource.BaseSingleDataSourceConfig[environmentCleanupPreviewModel, cleanupPreview]{
+				RecordSchema:        makeEnvironmentCleanupPreviewDataSourceSchema(),
+				MetadataTypeName:    "environment_cleanup_preview",
+				PrettyAttributeName: "environment cleanup preview",
+				FlattenAPIModel: func(ctx context.Context, apiObject *cleanupPreview, state *environmentCleanupPreviewModel) (diags diag.Diagnostics) {
+					setEnvironmentCleanupPreviewAttributes(ctx, state, apiObject)
+					return
+				},
+				GetAPIModel: func(ctx context.Context, tfModel environmentCleanupPreviewModel, client *bastionzero.Client) (*cleanupPreview, error) {
+					env, _, err := client.Environments.GetEnvironment(ctx, tfModel.EnvironmentID.ValueString())
+					if err != nil {
+						return nil, err
+					}
+
+					bzeroTargets, err := listcache.List(ctx, listcache.KindBzeroTarget, "", func(ctx context.Context) ([]targets.BzeroTarget, error) {
+						targets, _, err := client.Targets.ListBzeroTargets(ctx)
+						return targets, err
+					})
+					if err != nil {
+						return nil, err
+					}
+					clusterTargets, err := listcache.List(ctx, listcache.KindClusterTarget, "", func(ctx context.Context) ([]targets.ClusterTarget, error) {
+						targets, _, err := client.Targets.ListClusterTargets(ctx)
+						return targets, err
+					})
+					if err != nil {
+						return nil, err
+					}
+
+					agentTargets := make([]targets.TargetInterface, 0, len(bzeroTargets)+len(clusterTargets))
+					for i := range bzeroTargets {
+						agentTargets = append(agentTargets, &bzeroTargets[i])
+					}
+					for i := range clusterTargets {
+						agentTargets = append(agentTargets, &clusterTargets[i])
+					}
+
+					return buildCleanupPreview(env, agentTargets), nil
+				},
+				MarkdownDescription: "Get a preview of which targets in a BastionZero environment are offline and when BastionZero will automatically remove them, " +
+					"as configured by the environment's [`offline_cleanup_timeout_hours`](../resources/environment#offline_cleanup_timeout_hours). " +
+					"Only Bzero and Cluster targets, which run a BastionZero agent, are removed when offline.",
+			},
+		},
+	)
+}
+
+func makeEnvironmentCleanupPreviewDataSourceSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"environment_id": schema.StringAttribute{
+			Required:    true,
+			Description: "The environment's unique ID.",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+		},
+		"offline_cleanup_timeout_hours": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The amount of time (in hours) BastionZero waits until offline targets in the environment are automatically removed. If this value is `0`, then offline target cleanup is disabled.",
+		},
+		"offline_targets": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "List of the environment's offline targets, sorted by the time they will be removed (soonest first).",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: "The target's unique ID.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The target's name.",
+					},
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: fmt.Sprintf("The target's type %s.", internal.PrettyOneOf([]targettype.TargetType{targettype.Bzero, targettype.Cluster})),
+					},
+					"last_agent_update": schema.StringAttribute{
+						Computed:    true,
+						Description: fmt.Sprintf("The time this target's agent last had a transition change in status %s. Null if there has not been a single transition change.", internal.PrettyRFC3339Timestamp()),
+					},
+					"cleanup_time": schema.StringAttribute{
+						Computed: true,
+						Description: fmt.Sprintf("The time BastionZero will remove this target if it stays offline %s. ", internal.PrettyRFC3339Timestamp()) +
+							"Null if offline target cleanup is disabled or the target's `last_agent_update` is null.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildCleanupPreview returns the targets in env that are offline, according to
+// agentTargets, and the time BastionZero will remove each of them.
+func buildCleanupPreview(env *environments.Environment, agentTargets []targets.TargetInterface) *cleanupPreview {
+	agentTargetsByID := make(map[string]targets.TargetInterface, len(agentTargets))
+	for _, target := range agentTargets {
+		agentTargetsByID[target.GetID()] = target
+	}
+
+	preview := &cleanupPreview{
+		OfflineCleanupTimeoutHours: env.OfflineCleanupTimeoutHours,
+		OfflineTargets:             make([]offlineTarget, 0),
+	}
+	for _, summary := range env.Targets {
+		target, ok := agentTargetsByID[summary.ID]
+		if !ok || target.GetStatus() != targetstatus.Offline {
+			continue
+		}
+
+		offline := offlineTarget{
+			ID:   summary.ID,
+			Name: target.GetName(),
+			Type: summary.Type,
+		}
+		if lastAgentUpdate := target.GetLastAgentUpdate(); lastAgentUpdate != nil {
+			offline.LastAgentUpdate = bastionzero.PtrTo(lastAgentUpdate.UTC())
+			if env.OfflineCleanupTimeoutHours > 0 {
+				offline.CleanupTime = bastionzero.PtrTo(offline.LastAgentUpdate.Add(time.Duration(env.OfflineCleanupTimeoutHours) * time.Hour))
+			}
+		}
+		preview.OfflineTargets = append(preview.OfflineTargets, offline)
+	}
+
+	// Sort by cleanup time with targets that will not be removed last, and
+	// break ties by ID so the order is stable
+	sort.Slice(preview.OfflineTargets, func(i, j int) bool {
+		a, b := preview.OfflineTargets[i], preview.OfflineTargets[j]
+		switch {
+		case a.CleanupTime != nil && b.CleanupTime != nil && !a.CleanupTime.Equal(*b.CleanupTime):
+			return a.CleanupTime.Before(*b.CleanupTime)
+		case (a.CleanupTime == nil) != (b.CleanupTime == nil):
+			return a.CleanupTime != nil
+		default:
+			return a.ID < b.ID
+		}
+	})
+
+	return preview
+}
+
+// setEnvironmentCleanupPreviewAttributes populates the TF schema data from a
+// cleanup preview.
+func setEnvironmentCleanupPreviewAttributes(ctx context.Context, schema *environmentCleanupPreviewModel, preview *cleanupPreview) {
+	schema.OfflineCleanupTimeoutHours = types.Int64Value(int64(preview.OfflineCleanupTimeoutHours))
+
+	elementType := getOfflineTargetModelType(ctx)
+	attributeTypes := elementType.AttrTypes
+	offlineTargets := make([]attr.Value, 0, len(preview.OfflineTargets))
+	for _, target := range preview.OfflineTargets {
+		offlineTargets = append(offlineTargets, types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"id":                types.StringValue(target.ID),
+			"name":              types.StringValue(target.Name),
+			"type":              types.StringValue(string(target.Type)),
+			"last_agent_update": formatOptionalTime(target.LastAgentUpdate),
+			"cleanup_time":      formatOptionalTime(target.CleanupTime),
+		}))
+	}
+	schema.OfflineTargets = types.ListValueMust(elementType, offlineTargets)
+}
+
+// formatOptionalTime formats t as an RFC 3339 timestamp, or returns null if t
+// is nil.
+func formatOptionalTime(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
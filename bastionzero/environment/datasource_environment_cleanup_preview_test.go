@@ -0,0 +1,64 @@
+package environment_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEnvironmentCleanupPreviewDataSource_Basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	dataSourceName := "data.bastionzero_environment_cleanup_preview.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			// A new environment has no offline targets
+			{
+				Config: acctest.ConfigCompose(testAccEnvironmentConfigName(rName), testAccEnvironmentCleanupPreviewDataSourceConfig()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", dataSourceName, "environment_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "offline_cleanup_timeout_hours", dataSourceName, "offline_cleanup_timeout_hours"),
+					resource.TestCheckResourceAttr(dataSourceName, "offline_targets.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestEnvironmentCleanupPreviewDataSource_InvalidEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Bad environment id not permitted
+				Config:      testAccEnvironmentCleanupPreviewDataSourceConfigID("foo"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func testAccEnvironmentCleanupPreviewDataSourceConfig() string {
+	return `
+data "bastionzero_environment_cleanup_preview" "test" {
+  environment_id = bastionzero_environment.test.id
+}
+`
+}
+
+func testAccEnvironmentCleanupPreviewDataSourceConfigID(envID string) string {
+	return fmt.Sprintf(`
+data "bastionzero_environment_cleanup_preview" "test" {
+  environment_id = %[1]q
+}
+`, envID)
+}
@@ -46,6 +46,71 @@ func TestAccEnvironmentDataSource_ID(t *testing.T) {
 	})
 }
 
+func TestAccEnvironmentDataSource_Name(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	dataSourceName := "data.bastionzero_environment.test"
+	var env environments.Environment
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			// First create a resource
+			{
+				Config: testAccEnvironmentConfigName(rName),
+			},
+			// Then, check data source looked up by name matches environment we
+			// create
+			{
+				Config: acctest.ConfigCompose(testAccEnvironmentConfigName(rName), testAccEnvironmentDataSourceConfigName()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists(resourceName, &env),
+					resource.TestCheckResourceAttrPair(resourceName, "id", dataSourceName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "name", dataSourceName, "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "description", dataSourceName, "description"),
+					resource.TestCheckResourceAttrPair(resourceName, "targets", dataSourceName, "targets"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironmentDataSource_NameNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccEnvironmentDataSourceConfigWithNameAndTimeout(acctest.RandomName(), "5s"),
+				ExpectError: regexp.MustCompile(`No environment found with name`),
+			},
+		},
+	})
+}
+
+func TestEnvironmentDataSource_ExactlyOneOfIDOrName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Neither id nor name not permitted
+				Config:      testAccEnvironmentDataSourceConfigEmpty(),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				// Both id and name not permitted
+				Config:      testAccEnvironmentDataSourceConfigWithIDAndName("3c5d2a8a-0e4b-4c7f-9a43-1d6b2f6f0a51", "foo"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
 func TestEnvironmentDataSource_InvalidID(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
@@ -79,3 +144,38 @@ data "bastionzero_environment" "test" {
 }
 `, id)
 }
+
+func testAccEnvironmentDataSourceConfigName() string {
+	return `
+data "bastionzero_environment" "test" {
+  name = bastionzero_environment.test.name
+}
+`
+}
+
+func testAccEnvironmentDataSourceConfigWithNameAndTimeout(name string, timeout string) string {
+	return fmt.Sprintf(`
+data "bastionzero_environment" "test" {
+  name = %[1]q
+  timeouts = {
+    read = %[2]q
+  }
+}
+`, name, timeout)
+}
+
+func testAccEnvironmentDataSourceConfigWithIDAndName(id string, name string) string {
+	return fmt.Sprintf(`
+data "bastionzero_environment" "test" {
+  id   = %[1]q
+  name = %[2]q
+}
+`, id, name)
+}
+
+func testAccEnvironmentDataSourceConfigEmpty() string {
+	return `
+data "bastionzero_environment" "test" {
+}
+`
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -25,8 +26,10 @@ func NewEnvironmentsDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all environments in your BastionZero organization. An environment is a collection of targets.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]environments.Environment, error) {
-			environments, _, err := client.Environments.ListEnvironments(ctx)
-			return environments, err
+			return listcache.List(ctx, listcache.KindEnvironment, "", func(ctx context.Context) ([]environments.Environment, error) {
+				environments, _, err := client.Environments.ListEnvironments(ctx)
+				return environments, err
+			})
 		},
 	})
 }
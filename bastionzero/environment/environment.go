@@ -12,6 +12,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -51,6 +52,22 @@ type environmentModel struct {
 	Targets                    types.Map    `tfsdk:"targets"` // key is target id. value is environmentTargetModel
 }
 
+// environmentResourceModel maps the environment resource schema data.
+type environmentResourceModel struct {
+	ID                         types.String `tfsdk:"id"`
+	OrganizationID             types.String `tfsdk:"organization_id"`
+	IsDefault                  types.Bool   `tfsdk:"is_default"`
+	Name                       types.String `tfsdk:"name"`
+	Description                types.String `tfsdk:"description"`
+	TimeCreated                types.String `tfsdk:"time_created"`
+	OfflineCleanupTimeoutHours types.Int64  `tfsdk:"offline_cleanup_timeout_hours"`
+	Targets                    types.Map    `tfsdk:"targets"` // key is target id. value is environmentTargetModel
+
+	ExclusiveTargetIDs             types.Set    `tfsdk:"exclusive_target_ids"`
+	ForceDestroy                   types.Bool   `tfsdk:"force_destroy"`
+	ReassignTargetsToEnvironmentID types.String `tfsdk:"reassign_targets_to_environment_id"`
+}
+
 // environmentTargetModel maps target summary data.
 type environmentTargetModel struct {
 	ID   types.String `tfsdk:"id"`
@@ -85,6 +102,23 @@ func setEnvironmentAttributes(ctx context.Context, schema *environmentModel, env
 	schema.Targets = types.MapValueMust(elementType, targetsMap)
 }
 
+// setEnvironmentResourceAttributes populates the TF resource schema data from
+// an environment. Attributes that only exist in the resource schema are left
+// unchanged.
+func setEnvironmentResourceAttributes(ctx context.Context, schema *environmentResourceModel, env *environments.Environment) {
+	var model environmentModel
+	setEnvironmentAttributes(ctx, &model, env)
+
+	schema.ID = model.ID
+	schema.OrganizationID = model.OrganizationID
+	schema.IsDefault = model.IsDefault
+	schema.Name = model.Name
+	schema.Description = model.Description
+	schema.TimeCreated = model.TimeCreated
+	schema.OfflineCleanupTimeoutHours = model.OfflineCleanupTimeoutHours
+	schema.Targets = model.Targets
+}
+
 func makeEnvironmentResourceSchema() map[string]schema.Attribute {
 	res := map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -180,7 +214,9 @@ func makeEnvironmentResourceSchema() map[string]schema.Attribute {
 	return res
 }
 
-func readEnvironment(ctx context.Context, schema *environmentModel, client *bastionzero.Client) (found bool, diags diag.Diagnostics) {
+// readEnvironment refreshes schema from the environment with schema's ID. The
+// ownership marker is stripped from the refreshed description.
+func readEnvironment(ctx context.Context, schema *environmentResourceModel, client *bastionzero.Client, marker *ownership.Marker) (found bool, diags diag.Diagnostics) {
 	if schema.ID.IsUnknown() || schema.ID.IsNull() {
 		diags.AddError(
 			"Unexpected null ID in schema",
@@ -202,6 +238,7 @@ func readEnvironment(ctx context.Context, schema *environmentModel, client *bast
 	}
 	tflog.Debug(ctx, "Queried for environment")
 
-	setEnvironmentAttributes(ctx, schema, env)
+	setEnvironmentResourceAttributes(ctx, schema, env)
+	schema.Description = marker.StripValue(schema.Description)
 	return true, diags
 }
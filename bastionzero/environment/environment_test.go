@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -81,3 +84,54 @@ func TestSetTFEnvironmentAttributes(t *testing.T) {
 		require.EqualValues(t, c.expected, got)
 	}
 }
+
+func TestPrettyTargetList(t *testing.T) {
+	targetList := []environments.TargetSummary{
+		{ID: "b", Type: targettype.Web},
+		{ID: "a", Type: targettype.Bzero},
+	}
+
+	require.Equal(t, "  - a (Bzero)\n  - b (Web)\n", prettyTargetList(targetList))
+	// The caller's list is left unsorted
+	require.Equal(t, "b", targetList[0].ID)
+}
+
+func TestBuildCleanupPreview(t *testing.T) {
+	now := time.Now().UTC()
+	earlier := now.Add(-time.Hour)
+	env := &environments.Environment{
+		ID:                         "env",
+		OfflineCleanupTimeoutHours: 24,
+		Targets: []environments.TargetSummary{
+			{ID: "online", Type: targettype.Bzero},
+			{ID: "offline-now", Type: targettype.Bzero},
+			{ID: "offline-earlier", Type: targettype.Cluster},
+			{ID: "offline-never-updated", Type: targettype.Bzero},
+			{ID: "db", Type: targettype.Db},
+		},
+	}
+	agentTargets := []targets.TargetInterface{
+		&targets.BzeroTarget{BaseTarget: targets.BaseTarget{ID: "online", Name: "online", Status: targetstatus.Online, LastAgentUpdate: &types.Timestamp{Time: now}}},
+		&targets.BzeroTarget{BaseTarget: targets.BaseTarget{ID: "offline-now", Name: "offline-now", Status: targetstatus.Offline, LastAgentUpdate: &types.Timestamp{Time: now}}},
+		&targets.ClusterTarget{BaseTarget: targets.BaseTarget{ID: "offline-earlier", Name: "offline-earlier", Status: targetstatus.Offline, LastAgentUpdate: &types.Timestamp{Time: earlier}}},
+		&targets.BzeroTarget{BaseTarget: targets.BaseTarget{ID: "offline-never-updated", Name: "offline-never-updated", Status: targetstatus.Offline}},
+		// Not in the environment
+		&targets.BzeroTarget{BaseTarget: targets.BaseTarget{ID: "elsewhere", Name: "elsewhere", Status: targetstatus.Offline, LastAgentUpdate: &types.Timestamp{Time: now}}},
+	}
+
+	preview := buildCleanupPreview(env, agentTargets)
+	require.Equal(t, uint(24), preview.OfflineCleanupTimeoutHours)
+	require.Equal(t, []offlineTarget{
+		{ID: "offline-earlier", Name: "offline-earlier", Type: targettype.Cluster, LastAgentUpdate: &earlier, CleanupTime: bastionzero.PtrTo(earlier.Add(24 * time.Hour))},
+		{ID: "offline-now", Name: "offline-now", Type: targettype.Bzero, LastAgentUpdate: &now, CleanupTime: bastionzero.PtrTo(now.Add(24 * time.Hour))},
+		{ID: "offline-never-updated", Name: "offline-never-updated", Type: targettype.Bzero},
+	}, preview.OfflineTargets)
+
+	// Cleanup disabled
+	env.OfflineCleanupTimeoutHours = 0
+	preview = buildCleanupPreview(env, agentTargets)
+	require.Len(t, preview.OfflineTargets, 3)
+	for _, target := range preview.OfflineTargets {
+		require.Nil(t, target.CleanupTime)
+	}
+}
@@ -8,9 +8,23 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +34,7 @@ var (
 	_ resource.Resource                = &environmentResource{}
 	_ resource.ResourceWithConfigure   = &environmentResource{}
 	_ resource.ResourceWithImportState = &environmentResource{}
+	_ resource.ResourceWithModifyPlan  = &environmentResource{}
 )
 
 func NewEnvironmentResource() resource.Resource {
@@ -28,7 +43,11 @@ func NewEnvironmentResource() resource.Resource {
 
 // environmentResource is the resource implementation.
 type environmentResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -39,17 +58,21 @@ func (r *environmentResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the environment resource type name.
@@ -61,14 +84,59 @@ func (r *environmentResource) Metadata(_ context.Context, req resource.MetadataR
 func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Provides a BastionZero environment. An environment is a collection of targets.",
-		Attributes:          makeEnvironmentResourceSchema(),
+		Attributes:          makeEnvironmentResourceOnlySchema(makeEnvironmentResourceSchema()),
 	}
 }
 
+// makeEnvironmentResourceOnlySchema adds the attributes that control how the
+// environment resource manages the environment's targets, and so are not part
+// of the environment data sources, to attributes.
+func makeEnvironmentResourceOnlySchema(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["exclusive_target_ids"] = schema.SetAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Set of IDs of the targets that belong to this environment. If set, targets in this set are moved into the environment, and any other target in the environment is moved " +
+			"to the environment with ID `reassign_targets_to_environment_id`, or to the default environment if it is not set. If not set, the environment's targets are not managed. " +
+			"Do not use together with `bastionzero_environment_target_attachment` resources for the same environment.",
+		MarkdownDescription: "Set of IDs of the targets that belong to this environment. If set, targets in this set are moved into the environment, and any other target in the environment is moved " +
+			"to the environment with ID [`reassign_targets_to_environment_id`](#reassign_targets_to_environment_id), or to the default environment if it is not set. If not set, the environment's targets are not managed. " +
+			"Do not use together with [`bastionzero_environment_target_attachment`](../resources/environment_target_attachment) resources for the same environment.",
+		Validators: []validator.Set{
+			setvalidator.ValueStringsAre(bzvalidator.ValidUUIDV4()),
+		},
+	}
+	attributes["force_destroy"] = schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: "If `true`, the environment's targets are deleted when the environment is destroyed; `false` otherwise (Defaults to `false`). " +
+			"If `false` and `reassign_targets_to_environment_id` is not set, destroying an environment that contains targets fails. " +
+			"The value must be applied before the environment is destroyed for it to take effect.",
+		MarkdownDescription: "If `true`, the environment's targets are deleted when the environment is destroyed; `false` otherwise (Defaults to `false`). " +
+			"If `false` and [`reassign_targets_to_environment_id`](#reassign_targets_to_environment_id) is not set, destroying an environment that contains targets fails. " +
+			"The value must be applied before the environment is destroyed for it to take effect.",
+	}
+	attributes["reassign_targets_to_environment_id"] = schema.StringAttribute{
+		Optional: true,
+		Description: "ID of the environment to move the environment's targets to when the environment is destroyed. " +
+			"The value must be applied before the environment is destroyed for it to take effect.",
+		Validators: []validator.String{
+			bzvalidator.ValidUUIDV4(),
+			stringvalidator.ConflictsWith(path.MatchRoot("force_destroy")),
+		},
+	}
+
+	return attributes
+}
+
 // Create creates the environment resource and sets the initial Terraform state.
 func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindEnvironment)
+
 	// Read Terraform plan data into the model
-	var plan environmentModel
+	var plan environmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -77,7 +145,9 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	// Generate API request body from plan
 	createReq := new(environments.CreateEnvironmentRequest)
 	createReq.Name = plan.Name.ValueString()
-	createReq.Description = plan.Description.ValueString()
+	// Stamp the ownership marker so the environment can be identified as
+	// managed by Terraform
+	createReq.Description = r.marker.Stamp(plan.Description.ValueString())
 	createReq.OfflineCleanupTimeoutHours = uint(plan.OfflineCleanupTimeoutHours.ValueInt64())
 
 	ctx = tflog.SetField(ctx, "environment_name", createReq.Name)
@@ -98,8 +168,20 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	tflog.Debug(ctx, "Created environment")
 	plan.ID = types.StringValue(createResp.ID)
 
+	if !plan.ExclusiveTargetIDs.IsNull() {
+		resp.Diagnostics.Append(r.reconcileTargets(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			// Save the created environment so that it is not orphaned.
+			// Terraform marks it as tainted due to the error
+			if found, _ := readEnvironment(ctx, &plan, r.client, r.marker); found {
+				resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			}
+			return
+		}
+	}
+
 	// Query using the GET API to populate other attributes
-	found, diags := readEnvironment(ctx, &plan, r.client)
+	found, diags := readEnvironment(ctx, &plan, r.client, r.marker)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -111,20 +193,29 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_environment", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the environment Terraform state with the latest data.
 func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
-	var state environmentModel
+	var state environmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx = tflog.SetField(ctx, "environment_id", state.ID.ValueString())
 
+	// Imported environments have no value for the resource-only attributes
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
 	// Read environment
-	found, diags := readEnvironment(ctx, &state, r.client)
+	found, diags := readEnvironment(ctx, &state, r.client, r.marker)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -135,6 +226,15 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Detect targets that joined or left the environment outside of Terraform
+	if !state.ExclusiveTargetIDs.IsNull() {
+		targetIDs := make([]string, 0, len(state.Targets.Elements()))
+		for targetID := range state.Targets.Elements() {
+			targetIDs = append(targetIDs, targetID)
+		}
+		state.ExclusiveTargetIDs = internal.FlattenFrameworkSet(ctx, types.StringType, targetIDs, func(id string) attr.Value { return types.StringValue(id) })
+	}
+
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -142,8 +242,12 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 // Update updates the environment resource and sets the updated Terraform state
 // on success.
 func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindEnvironment)
+
 	// Read Terraform plan and current state data into the model
-	var plan, state environmentModel
+	var plan, state environmentResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -159,24 +263,34 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 		modifyReq.Name = bastionzero.PtrTo(plan.Name.ValueString())
 	}
 	if !plan.Description.Equal(state.Description) {
-		modifyReq.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modifyReq.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.OfflineCleanupTimeoutHours.Equal(state.OfflineCleanupTimeoutHours) {
 		modifyReq.OfflineCleanupTimeoutHours = bastionzero.PtrTo(uint(plan.OfflineCleanupTimeoutHours.ValueInt64()))
 	}
 
-	// Update existing environment
-	_, err := r.client.Environments.ModifyEnvironment(ctx, plan.ID.ValueString(), modifyReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating environment",
-			"Could not update environment, unexpected error: "+err.Error(),
-		)
-		return
+	// Update existing environment. There is nothing to send if only
+	// resource-only attributes, such as force_destroy, have changed
+	if modifyReq.Name != nil || modifyReq.Description != nil || modifyReq.OfflineCleanupTimeoutHours != nil {
+		_, err := r.client.Environments.ModifyEnvironment(ctx, plan.ID.ValueString(), modifyReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating environment",
+				"Could not update environment, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.ExclusiveTargetIDs.IsNull() {
+		resp.Diagnostics.Append(r.reconcileTargets(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Query using the GET API to populate other attributes
-	found, diags := readEnvironment(ctx, &plan, r.client)
+	found, diags := readEnvironment(ctx, &plan, r.client, r.marker)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -188,33 +302,63 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_environment", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the environment resource and removes the Terraform state on
-// success.
+// success. Any targets in the environment are first moved to the environment
+// with ID reassign_targets_to_environment_id, or deleted if force_destroy is
+// true. The default environment cannot be deleted.
 func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindEnvironment)
+	defer r.listCache.Invalidate(targetKinds...)
+
 	// Retrieve values from state
-	var state environmentModel
+	var state environmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	ctx = tflog.SetField(ctx, "environment_id", state.ID.ValueString())
 
+	// Targets may have joined or left the environment since the state was
+	// refreshed, so get the environment's current targets
+	tflog.Debug(ctx, "Querying for environment")
+	env, _, err := r.client.Environments.GetEnvironment(ctx, state.ID.ValueString())
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		// Return early without error if environment is already deleted
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting environment",
+			"Could not read environment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	// Present user-friendly error instead of internal server error if
-	// environment contains targets
-	if len(state.Targets.Elements()) > 0 {
+	// environment is the default environment
+	if env.IsDefault {
 		resp.Diagnostics.AddError(
 			"Error deleting environment",
-			fmt.Sprintf("Cannot delete an environment with targets in it. Environment %s contains %d target(s).\n", state.ID.ValueString(), len(state.Targets.Elements()))+
-				"Please remove all targets from this environment before destroying.",
+			fmt.Sprintf("Cannot delete the default environment. Environment %s is the organization's default environment. ", state.ID.ValueString())+
+				"Please make another environment the default environment in the BastionZero web app before destroying.",
 		)
 		return
 	}
 
+	if len(env.Targets) > 0 {
+		resp.Diagnostics.Append(r.removeTargets(ctx, &state, env.Targets)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Delete existing environment
 	tflog.Debug(ctx, "Deleting environment")
-	_, err := r.client.Environments.DeleteEnvironment(ctx, state.ID.ValueString())
+	_, err = r.client.Environments.DeleteEnvironment(ctx, state.ID.ValueString())
 
 	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
 		// Return early without error if environment is already deleted
@@ -227,9 +371,78 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 	tflog.Debug(ctx, "Deleted environment")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_environment", state.ID.ValueString(), req.State)...)
+}
+
+// reconcileTargets makes the targets with IDs in plan's exclusive_target_ids
+// the only targets in the environment described by plan.
+func (r *environmentResource) reconcileTargets(ctx context.Context, plan *environmentResourceModel) diag.Diagnostics {
+	defer r.listCache.Invalidate(targetKinds...)
+
+	targetIDs := internal.ExpandFrameworkStringSet(ctx, plan.ExclusiveTargetIDs)
+	return reconcileExclusiveTargets(listcache.NewContext(ctx, r.listCache), r.client, plan.ID.ValueString(), targetIDs, plan.ReassignTargetsToEnvironmentID.ValueString())
+}
+
+// removeTargets empties the environment described by state of targetList
+// before it is deleted, as configured by state's reassign_targets_to_environment_id
+// and force_destroy attributes.
+func (r *environmentResource) removeTargets(ctx context.Context, state *environmentResourceModel, targetList []environments.TargetSummary) (diags diag.Diagnostics) {
+	switch {
+	case !state.ReassignTargetsToEnvironmentID.IsNull():
+		reassignToID := state.ReassignTargetsToEnvironmentID.ValueString()
+		if reassignToID == state.ID.ValueString() {
+			diags.AddError(
+				"Error deleting environment",
+				fmt.Sprintf("Cannot reassign the targets of environment %s to itself. Please set reassign_targets_to_environment_id to the ID of another environment.", reassignToID),
+			)
+			return
+		}
+
+		for _, target := range targetList {
+			tflog.Info(ctx, "Reassigning target to another environment", map[string]any{"target_id": target.ID, "target_type": target.Type, "to_environment_id": reassignToID})
+			if err := moveTarget(ctx, r.client, target, reassignToID); err != nil {
+				diags.AddError(
+					"Error deleting environment",
+					fmt.Sprintf("Could not reassign target %s to environment %s, unexpected error: %s", target.ID, reassignToID, err),
+				)
+				return
+			}
+		}
+	case state.ForceDestroy.ValueBool():
+		for _, target := range targetList {
+			tflog.Info(ctx, "Deleting target in environment", map[string]any{"target_id": target.ID, "target_type": target.Type})
+			if err := deleteTarget(ctx, r.client, target); err != nil && !apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+				diags.AddError(
+					"Error deleting environment",
+					fmt.Sprintf("Could not delete target %s, unexpected error: %s", target.ID, err),
+				)
+				return
+			}
+		}
+	default:
+		// Present user-friendly error instead of internal server error if
+		// environment contains targets
+		diags.AddError(
+			"Error deleting environment",
+			fmt.Sprintf("Cannot delete an environment with targets in it. Environment %s contains %d target(s):\n%s\n", state.ID.ValueString(), len(targetList), prettyTargetList(targetList))+
+				"Please remove all targets from this environment, set reassign_targets_to_environment_id to move them to another environment, "+
+				"or set force_destroy to delete them, before destroying.",
+		)
+	}
+
+	return
 }
 
 func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("environment", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("reassign_targets_to_environment_id"), req, resp)
 }
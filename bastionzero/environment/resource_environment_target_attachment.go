@@ -0,0 +1,306 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &environmentTargetAttachmentResource{}
+	_ resource.ResourceWithConfigure   = &environmentTargetAttachmentResource{}
+	_ resource.ResourceWithImportState = &environmentTargetAttachmentResource{}
+	_ resource.ResourceWithModifyPlan  = &environmentTargetAttachmentResource{}
+)
+
+// environmentTargetAttachmentModel maps the environment target attachment
+// schema data.
+type environmentTargetAttachmentModel struct {
+	ID            types.String `tfsdk:"id"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+	TargetID      types.String `tfsdk:"target_id"`
+	TargetType    types.String `tfsdk:"target_type"`
+}
+
+// setEnvironmentTargetAttachmentAttributes populates the TF schema data from
+// the location of the attached target.
+func setEnvironmentTargetAttachmentAttributes(schema *environmentTargetAttachmentModel, location *targetLocation) {
+	schema.ID = types.StringValue(location.ID)
+	schema.TargetID = types.StringValue(location.ID)
+	schema.TargetType = types.StringValue(string(location.Type))
+	schema.EnvironmentID = types.StringValue(location.EnvironmentID)
+}
+
+func NewEnvironmentTargetAttachmentResource() resource.Resource {
+	return &environmentTargetAttachmentResource{}
+}
+
+// environmentTargetAttachmentResource is the resource implementation.
+type environmentTargetAttachmentResource struct {
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client to the
+// resource.
+func (r *environmentTargetAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
+}
+
+// Metadata returns the environment target attachment resource type name.
+func (r *environmentTargetAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_target_attachment"
+}
+
+// Schema defines the schema for the environment target attachment resource.
+func (r *environmentTargetAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches an existing target to a BastionZero environment by moving the target into it. " +
+			"Other targets in the environment are left unchanged. " +
+			"Destroying the attachment leaves the target in the environment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The attached target's unique ID.",
+			},
+			"environment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the environment to attach the target to.",
+				Validators: []validator.String{
+					bzvalidator.ValidUUIDV4(),
+				},
+			},
+			"target_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the target to attach.",
+				Validators: []validator.String{
+					bzvalidator.ValidUUIDV4(),
+				},
+			},
+			"target_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: fmt.Sprintf("The target's type %s.", internal.PrettyOneOf(targettype.TargetTypeValues())),
+			},
+		},
+	}
+}
+
+// Create moves the target into the environment and sets the initial Terraform
+// state.
+func (r *environmentTargetAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment_target_attachment", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindEnvironment)
+	defer r.listCache.Invalidate(targetKinds...)
+	ctx = listcache.NewContext(ctx, r.listCache)
+
+	// Read Terraform plan data into the model
+	var plan environmentTargetAttachmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "target_id", plan.TargetID.ValueString())
+	ctx = tflog.SetField(ctx, "environment_id", plan.EnvironmentID.ValueString())
+
+	location, found := r.findTarget(listcache.WithRefresh(ctx), plan.TargetID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error attaching target to environment",
+			fmt.Sprintf("Could not find target %s in any environment.", plan.TargetID.ValueString()),
+		)
+		return
+	}
+
+	if location.EnvironmentID != plan.EnvironmentID.ValueString() {
+		tflog.Debug(ctx, "Moving target into environment")
+		if err := moveTarget(ctx, r.client, location.TargetSummary, plan.EnvironmentID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error attaching target to environment",
+				"Could not move target into environment, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, "Moved target into environment")
+		location.EnvironmentID = plan.EnvironmentID.ValueString()
+	}
+	setEnvironmentTargetAttachmentAttributes(&plan, location)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_environment_target_attachment", plan.ID.ValueString(), resp.State)...)
+}
+
+// Read refreshes the environment target attachment Terraform state with the
+// latest data.
+func (r *environmentTargetAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment_target_attachment", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	ctx = listcache.NewContext(ctx, r.listCache)
+
+	// Read Terraform prior state data into the model
+	var state environmentTargetAttachmentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "target_id", state.TargetID.ValueString())
+
+	location, found := r.findTarget(ctx, state.TargetID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// The next terraform plan will recreate the resource
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setEnvironmentTargetAttachmentAttributes(&state, location)
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update moves the target into the planned environment and sets the updated
+// Terraform state on success.
+func (r *environmentTargetAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment_target_attachment", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindEnvironment)
+	defer r.listCache.Invalidate(targetKinds...)
+
+	// Read Terraform plan and current state data into the model
+	var plan, state environmentTargetAttachmentModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "target_id", plan.TargetID.ValueString())
+	ctx = tflog.SetField(ctx, "environment_id", plan.EnvironmentID.ValueString())
+
+	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
+		target := environments.TargetSummary{
+			ID:   state.TargetID.ValueString(),
+			Type: targettype.TargetType(state.TargetType.ValueString()),
+		}
+
+		tflog.Debug(ctx, "Moving target into environment")
+		if err := moveTarget(ctx, r.client, target, plan.EnvironmentID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error attaching target to environment",
+				"Could not move target into environment, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, "Moved target into environment")
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_environment_target_attachment", plan.ID.ValueString(), req.State, resp.State)...)
+}
+
+// Delete removes the environment target attachment from the Terraform state.
+// A target always belongs to an environment, so the target is left where it
+// is.
+func (r *environmentTargetAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_environment_target_attachment", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+
+	// Retrieve values from state
+	var state environmentTargetAttachmentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Leaving target in environment", map[string]any{"target_id": state.TargetID.ValueString(), "environment_id": state.EnvironmentID.ValueString()})
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_environment_target_attachment", state.ID.ValueString(), req.State)...)
+}
+
+// ImportState imports the attachment of the target with the given ID to the
+// environment it currently belongs to.
+func (r *environmentTargetAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id and target_id attributes
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("target_id"), req, resp)
+}
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it.
+func (r *environmentTargetAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("environment target attachment", req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+}
+
+// findTarget returns the location of the target with ID targetID, and whether
+// it was found. Errors are added to diags.
+func (r *environmentTargetAttachmentResource) findTarget(ctx context.Context, targetID string, diags *diag.Diagnostics) (*targetLocation, bool) {
+	tflog.Debug(ctx, "Querying for target's environment")
+	locations, _, err := listTargetLocations(ctx, r.client)
+	if err != nil {
+		diags.AddError(
+			"Error reading target's environment",
+			"Could not list environments, unexpected error: "+err.Error(),
+		)
+		return nil, false
+	}
+	tflog.Debug(ctx, "Queried for target's environment")
+
+	location, ok := locations[targetID]
+	return &location, ok
+}
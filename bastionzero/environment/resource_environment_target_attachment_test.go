@@ -0,0 +1,121 @@
+package environment_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEnvironmentTargetAttachment_Basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment_target_attachment.test"
+	var envA, envB environments.Environment
+	var targetID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			// Verify create moves the target into the environment
+			{
+				Config: testAccEnvironmentTargetAttachmentConfig(rName, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists("bastionzero_environment.a", &envA),
+					testAccCheckEnvironmentExists("bastionzero_environment.b", &envB),
+					resource.TestCheckResourceAttrPair(resourceName, "environment_id", "bastionzero_environment.b", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "target_id", "bastionzero_db_target.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "id", "bastionzero_db_target.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "target_type", string(targettype.Db)),
+					resource.TestCheckResourceAttrWith(resourceName, "target_id", func(value string) error {
+						targetID = value
+						return nil
+					}),
+					testAccCheckDbTargetInEnvironment(&targetID, &envB),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update moves the target to the new environment
+			{
+				Config: testAccEnvironmentTargetAttachmentConfig(rName, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "environment_id", "bastionzero_environment.a", "id"),
+					testAccCheckDbTargetInEnvironment(&targetID, &envA),
+				),
+			},
+		},
+	})
+}
+
+func TestEnvironmentTargetAttachment_InvalidID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Bad target id not permitted
+				Config:      testAccEnvironmentTargetAttachmentConfigIDs("3c5d2a8a-0e4b-4c7f-9a43-1d6b2f6f0a51", "foo"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			{
+				// Bad environment id not permitted
+				Config:      testAccEnvironmentTargetAttachmentConfigIDs("foo", "3c5d2a8a-0e4b-4c7f-9a43-1d6b2f6f0a51"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+// testAccEnvironmentTargetAttachmentConfig creates a db target in environment
+// "a" and attaches it to the environment named attachTo.
+func testAccEnvironmentTargetAttachmentConfig(rName string, attachTo string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment" "a" {
+  name = "%[1]s-a"
+}
+
+resource "bastionzero_environment" "b" {
+  name = "%[1]s-b"
+  # The db target may still be attached to this environment when it is
+  # destroyed
+  force_destroy = true
+}
+
+resource "bastionzero_db_target" "test" {
+  environment_id = bastionzero_environment.a.id
+  name = %[1]q
+  proxy_environment_id = bastionzero_environment.a.id
+  remote_host = "localhost"
+  remote_port = 5432
+
+  lifecycle {
+    ignore_changes = [environment_id]
+  }
+}
+
+resource "bastionzero_environment_target_attachment" "test" {
+  environment_id = bastionzero_environment.%[2]s.id
+  target_id = bastionzero_db_target.test.id
+}
+`, rName, attachTo)
+}
+
+func testAccEnvironmentTargetAttachmentConfigIDs(envID string, targetID string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment_target_attachment" "test" {
+  environment_id = %[1]q
+  target_id = %[2]q
+}
+`, envID, targetID)
+}
@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/environment"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -270,6 +272,164 @@ func TestAccEnvironment_OfflineCleanupTimeoutHours(t *testing.T) {
 	})
 }
 
+func TestAccEnvironment_ForceDestroy(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	var env environments.Environment
+	var targetID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckEnvironmentDestroy,
+			// Check the target created outside of Terraform was deleted with
+			// the environment
+			testAccCheckDbTargetDeleted(&targetID),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentConfigForceDestroy(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists(resourceName, &env),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					// Add a target that Terraform does not know about to the
+					// environment
+					testAccCreateDbTargetInEnvironment(&env, &targetID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironment_ReassignTargetsToEnvironmentID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	destResourceName := "bastionzero_environment.dest"
+	var env, destEnv environments.Environment
+	var targetID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentConfigReassignTargets(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists(resourceName, &env),
+					testAccCheckEnvironmentExists(destResourceName, &destEnv),
+					resource.TestCheckResourceAttrPair(resourceName, "reassign_targets_to_environment_id", destResourceName, "id"),
+					testAccCreateDbTargetInEnvironment(&env, &targetID),
+				),
+			},
+			// Destroy only the environment with the target, which must move the
+			// target to the destination environment
+			{
+				Config: testAccEnvironmentConfigReassignTargetsDestOnly(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbTargetInEnvironment(&targetID, &destEnv),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironment_DestroyWithTargets(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	var env environments.Environment
+	var targetID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentConfigName(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists(resourceName, &env),
+					testAccCreateDbTargetInEnvironment(&env, &targetID),
+				),
+			},
+			// Destroying an environment that still has targets fails and lists
+			// the targets
+			{
+				Config:      testAccEnvironmentConfigName(rName),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Cannot delete an environment with targets in it`),
+			},
+			// Allow the environment to be cleaned up
+			{
+				Config: testAccEnvironmentConfigForceDestroy(rName),
+			},
+		},
+	})
+}
+
+func TestAccEnvironment_ExclusiveTargetIDs(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_environment.test"
+	var env, sourceEnv environments.Environment
+	var targetID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(ctx, t) },
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckEnvironmentDestroy,
+		Steps: []resource.TestStep{
+			// Verify the target is moved into the environment
+			{
+				Config: testAccEnvironmentConfigExclusiveTargetIDs(rName, "[bastionzero_db_target.test.id]"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvironmentExists(resourceName, &env),
+					testAccCheckEnvironmentExists("bastionzero_environment.source", &sourceEnv),
+					resource.TestCheckResourceAttr(resourceName, "exclusive_target_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "targets.%", "1"),
+					resource.TestCheckResourceAttrWith("bastionzero_db_target.test", "id", func(value string) error {
+						targetID = value
+						return nil
+					}),
+					testAccCheckDbTargetInEnvironment(&targetID, &env),
+				),
+			},
+			// Verify the target is moved out of the environment when it is
+			// removed from the set
+			{
+				Config: testAccEnvironmentConfigExclusiveTargetIDs(rName, "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "exclusive_target_ids.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "targets.%", "0"),
+					testAccCheckDbTargetInEnvironment(&targetID, &sourceEnv),
+				),
+			},
+		},
+	})
+}
+
+func TestEnvironment_ReassignTargetsConflictsWithForceDestroy(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bastionzero_environment" "test" {
+  name                               = "test"
+  force_destroy                      = true
+  reassign_targets_to_environment_id = "3c5d2a8a-0e4b-4c7f-9a43-1d6b2f6f0a51"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
 func TestEnvironment_InvalidName(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
@@ -327,6 +487,63 @@ resource "bastionzero_environment" "test" {
 `, rName, timeoutHours)
 }
 
+func testAccEnvironmentConfigForceDestroy(rName string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment" "test" {
+  name = %[1]q
+  force_destroy = true
+}
+`, rName)
+}
+
+func testAccEnvironmentConfigReassignTargetsDestOnly(rName string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment" "dest" {
+  name = "%[1]s-dest"
+  force_destroy = true
+}
+`, rName)
+}
+
+func testAccEnvironmentConfigReassignTargets(rName string) string {
+	return acctest.ConfigCompose(testAccEnvironmentConfigReassignTargetsDestOnly(rName), fmt.Sprintf(`
+resource "bastionzero_environment" "test" {
+  name = %[1]q
+  reassign_targets_to_environment_id = bastionzero_environment.dest.id
+}
+`, rName))
+}
+
+// testAccEnvironmentConfigExclusiveTargetIDs creates a db target in
+// environment "source" and an environment with exclusive_target_ids set to
+// targetIDs, a Terraform list expression, that moves evicted targets back to
+// "source".
+func testAccEnvironmentConfigExclusiveTargetIDs(rName string, targetIDs string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment" "source" {
+  name = "%[1]s-source"
+}
+
+resource "bastionzero_db_target" "test" {
+  environment_id = bastionzero_environment.source.id
+  name = %[1]q
+  proxy_environment_id = bastionzero_environment.source.id
+  remote_host = "localhost"
+  remote_port = 5432
+
+  lifecycle {
+    ignore_changes = [environment_id]
+  }
+}
+
+resource "bastionzero_environment" "test" {
+  name = %[1]q
+  exclusive_target_ids = %[2]s
+  reassign_targets_to_environment_id = bastionzero_environment.source.id
+}
+`, rName, targetIDs)
+}
+
 type expectedEnvironment struct {
 	Name                       *string
 	Description                *string
@@ -389,3 +606,54 @@ func testAccCheckEnvironmentRecreated(t *testing.T, before, after *environments.
 		return nil
 	}
 }
+
+// testAccCreateDbTargetInEnvironment creates a db target, which is proxied by
+// env, in env outside of Terraform and stores its ID at the provided pointer.
+func testAccCreateDbTargetInEnvironment(env *environments.Environment, targetID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		createResp, _, err := acctest.APIClient.Targets.CreateDatabaseTarget(context.Background(), &targets.CreateDatabaseTargetRequest{
+			TargetName:         acctest.RandomName(),
+			ProxyEnvironmentID: env.ID,
+			RemoteHost:         "localhost",
+			RemotePort:         targets.Port{Value: bastionzero.PtrTo(5432)},
+			EnvironmentID:      env.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create db target in environment %s: %w", env.ID, err)
+		}
+		*targetID = createResp.TargetId
+
+		return nil
+	}
+}
+
+// testAccCheckDbTargetInEnvironment checks the db target with the provided ID
+// belongs to env.
+func testAccCheckDbTargetInEnvironment(targetID *string, env *environments.Environment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		target, _, err := acctest.APIClient.Targets.GetDatabaseTarget(context.Background(), *targetID)
+		if err != nil {
+			return err
+		}
+		if target.EnvironmentID != env.ID {
+			return fmt.Errorf("Bad environment_id for target %s, expected \"%s\", got: %#v", *targetID, env.ID, target.EnvironmentID)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckDbTargetDeleted checks the db target with the provided ID no
+// longer exists at BastionZero.
+func testAccCheckDbTargetDeleted(targetID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, _, err := acctest.APIClient.Targets.GetDatabaseTarget(context.Background(), *targetID)
+		if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Db target %s still exists", *targetID)
+	}
+}
@@ -0,0 +1,180 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// targetKinds are the list cache kinds of every type of target an environment
+// can contain.
+var targetKinds = []listcache.Kind{
+	listcache.KindBzeroTarget,
+	listcache.KindClusterTarget,
+	listcache.KindDbTarget,
+	listcache.KindWebTarget,
+	listcache.KindDynamicAccessConfiguration,
+}
+
+// moveTarget moves target to the environment with ID environmentID.
+func moveTarget(ctx context.Context, client *bastionzero.Client, target environments.TargetSummary, environmentID string) error {
+	var err error
+	switch target.Type {
+	case targettype.Bzero:
+		_, _, err = client.Targets.ModifyBzeroTarget(ctx, target.ID, &targets.ModifyBzeroTargetRequest{EnvironmentID: &environmentID})
+	case targettype.Cluster:
+		_, _, err = client.Targets.ModifyClusterTarget(ctx, target.ID, &targets.ModifyClusterTargetRequest{EnvironmentID: &environmentID})
+	case targettype.Db:
+		_, _, err = client.Targets.ModifyDatabaseTarget(ctx, target.ID, &targets.ModifyDatabaseTargetRequest{EnvironmentID: &environmentID})
+	case targettype.Web:
+		_, _, err = client.Targets.ModifyWebTarget(ctx, target.ID, &targets.ModifyWebTargetRequest{EnvironmentID: &environmentID})
+	case targettype.DynamicAccessConfig:
+		_, _, err = client.Targets.ModifyDynamicAccessConfiguration(ctx, target.ID, &targets.ModifyDynamicAccessConfigurationRequest{EnvironmentId: &environmentID})
+	default:
+		return fmt.Errorf("cannot move target %s: unsupported target type %s", target.ID, target.Type)
+	}
+	return err
+}
+
+// deleteTarget deletes target from BastionZero.
+func deleteTarget(ctx context.Context, client *bastionzero.Client, target environments.TargetSummary) error {
+	var err error
+	switch target.Type {
+	case targettype.Bzero:
+		_, err = client.Targets.DeleteBzeroTarget(ctx, target.ID)
+	case targettype.Cluster:
+		_, err = client.Targets.DeleteClusterTarget(ctx, target.ID)
+	case targettype.Db:
+		_, err = client.Targets.DeleteDatabaseTarget(ctx, target.ID)
+	case targettype.Web:
+		_, err = client.Targets.DeleteWebTarget(ctx, target.ID)
+	case targettype.DynamicAccessConfig:
+		_, err = client.Targets.DeleteDynamicAccessConfiguration(ctx, target.ID)
+	default:
+		return fmt.Errorf("cannot delete target %s: unsupported target type %s", target.ID, target.Type)
+	}
+	return err
+}
+
+// targetLocation is a target and the ID of the environment it belongs to.
+type targetLocation struct {
+	environments.TargetSummary
+	EnvironmentID string
+}
+
+// listTargetLocations returns the location of every target in the
+// organization, keyed by target ID, and the ID of the default environment.
+// Callers that move targets based on the result should pass a context created
+// with listcache.WithRefresh.
+func listTargetLocations(ctx context.Context, client *bastionzero.Client) (locations map[string]targetLocation, defaultEnvironmentID string, err error) {
+	envs, err := listcache.List(ctx, listcache.KindEnvironment, "", func(ctx context.Context) ([]environments.Environment, error) {
+		envs, _, err := client.Environments.ListEnvironments(ctx)
+		return envs, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	locations = make(map[string]targetLocation)
+	for _, env := range envs {
+		if env.IsDefault {
+			defaultEnvironmentID = env.ID
+		}
+		for _, target := range env.Targets {
+			locations[target.ID] = targetLocation{TargetSummary: target, EnvironmentID: env.ID}
+		}
+	}
+	return locations, defaultEnvironmentID, nil
+}
+
+// reconcileExclusiveTargets moves the targets with IDs targetIDs into the
+// environment with ID environmentID, and moves every other target in it out to
+// the environment with ID evictToEnvironmentID, or to the default environment
+// if evictToEnvironmentID is empty.
+func reconcileExclusiveTargets(ctx context.Context, client *bastionzero.Client, environmentID string, targetIDs []string, evictToEnvironmentID string) (diags diag.Diagnostics) {
+	locations, defaultEnvironmentID, err := listTargetLocations(listcache.WithRefresh(ctx), client)
+	if err != nil {
+		diags.AddError(
+			"Error reconciling environment targets",
+			"Could not list environments, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	wanted := make(map[string]bool, len(targetIDs))
+	for _, targetID := range targetIDs {
+		wanted[targetID] = true
+
+		location, ok := locations[targetID]
+		if !ok {
+			diags.AddError(
+				"Error reconciling environment targets",
+				fmt.Sprintf("Could not find target %s in any environment.", targetID),
+			)
+			return
+		}
+		if location.EnvironmentID == environmentID {
+			continue
+		}
+
+		tflog.Info(ctx, "Moving target into environment", map[string]any{"target_id": targetID, "target_type": location.Type, "from_environment_id": location.EnvironmentID})
+		if err := moveTarget(ctx, client, location.TargetSummary, environmentID); err != nil {
+			diags.AddError(
+				"Error reconciling environment targets",
+				fmt.Sprintf("Could not move target %s into environment %s, unexpected error: %s", targetID, environmentID, err),
+			)
+			return
+		}
+	}
+
+	if evictToEnvironmentID == "" {
+		evictToEnvironmentID = defaultEnvironmentID
+	}
+	for targetID, location := range locations {
+		if location.EnvironmentID != environmentID || wanted[targetID] {
+			continue
+		}
+		if evictToEnvironmentID == "" || evictToEnvironmentID == environmentID {
+			diags.AddError(
+				"Error reconciling environment targets",
+				fmt.Sprintf("Cannot move target %s out of environment %s as there is no other environment to move it to. ", targetID, environmentID)+
+					"Please set reassign_targets_to_environment_id to the ID of another environment.",
+			)
+			return
+		}
+
+		tflog.Info(ctx, "Moving target out of environment", map[string]any{"target_id": targetID, "target_type": location.Type, "to_environment_id": evictToEnvironmentID})
+		if err := moveTarget(ctx, client, location.TargetSummary, evictToEnvironmentID); err != nil {
+			diags.AddError(
+				"Error reconciling environment targets",
+				fmt.Sprintf("Could not move target %s to environment %s, unexpected error: %s", targetID, evictToEnvironmentID, err),
+			)
+			return
+		}
+	}
+
+	return
+}
+
+// prettyTargetList returns a bulleted list of targets, sorted by ID, for use in
+// diagnostics.
+func prettyTargetList(targetList []environments.TargetSummary) string {
+	sorted := make([]environments.TargetSummary, len(targetList))
+	copy(sorted, targetList)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var b strings.Builder
+	for _, target := range sorted {
+		fmt.Fprintf(&b, "  - %s (%s)\n", target.ID, target.Type)
+	}
+	return b.String()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/organization"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -43,8 +44,10 @@ func NewGroupsDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all groups in your BastionZero organization. A group is an Identity provider (IdP) group synced to BastionZero.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]organization.Group, error) {
-			groups, _, err := client.Organization.ListGroups(ctx)
-			return groups, err
+			return listcache.List(ctx, listcache.KindGroup, "", func(ctx context.Context) ([]organization.Group, error) {
+				groups, _, err := client.Organization.ListGroups(ctx)
+				return groups, err
+			})
 		},
 	})
 }
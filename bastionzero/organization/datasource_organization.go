@@ -0,0 +1,50 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/organization"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// organizationModel maps the organization schema data.
+type organizationModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return bzdatasource.NewSingleDataSource(
+		&bzdatasource.SingleDataSourceConfig[organizationModel, organization.UserOrganization]{
+			BaseSingleDataSourceConfig: &bzdatasource.BaseSingleDataSourceConfig[organizationModel, organization.UserOrganization]{
+				RecordSchema: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: "The organization's unique ID.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The organization's name.",
+					},
+				},
+				MetadataTypeName:    "organization",
+				PrettyAttributeName: "organization",
+				FlattenAPIModel: func(ctx context.Context, apiObject *organization.UserOrganization, state *organizationModel) (diags diag.Diagnostics) {
+					state.ID = types.StringValue(apiObject.ID)
+					state.Name = types.StringValue(apiObject.Name)
+					return
+				},
+				GetAPIModel: func(ctx context.Context, _ organizationModel, client *bastionzero.Client) (*organization.UserOrganization, error) {
+					org, _, err := client.Organization.GetUserOrganization(ctx)
+					return org, err
+				},
+				MarkdownDescription: "Get information about the BastionZero organization the provider's credentials belong to.",
+			},
+		},
+	)
+}
@@ -0,0 +1,42 @@
+package organization_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationDataSource_Basic(t *testing.T) {
+	ctx := context.Background()
+	dataSourceName := "data.bastionzero_organization.test"
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	org, _, err := acctest.APIClient.Organization.GetUserOrganization(ctx)
+	if err != nil {
+		t.Fatalf("failed to get organization: %s", err)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", org.ID),
+					resource.TestCheckResourceAttr(dataSourceName, "name", org.Name),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationDataSourceConfig() string {
+	return `
+data "bastionzero_organization" "test" {
+}
+`
+}
@@ -0,0 +1,378 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &unmanagedObjectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &unmanagedObjectsDataSource{}
+)
+
+// unmanagedObjectsModel maps the unmanaged objects schema data.
+type unmanagedObjectsModel struct {
+	Marker       types.String `tfsdk:"ownership_marker"`
+	Environments types.List   `tfsdk:"environments"`
+	Policies     types.List   `tfsdk:"policies"`
+	DbTargets    types.List   `tfsdk:"db_targets_in_unmanaged_environments"`
+}
+
+// unmanagedEnvironmentModel maps unmanaged environment data.
+type unmanagedEnvironmentModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// unmanagedPolicyModel maps unmanaged policy data.
+type unmanagedPolicyModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+}
+
+// unmanagedDbTargetModel maps unmanaged db target data.
+type unmanagedDbTargetModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	EnvironmentID types.String `tfsdk:"environment_id"`
+}
+
+func NewUnmanagedObjectsDataSource() datasource.DataSource {
+	return &unmanagedObjectsDataSource{}
+}
+
+// unmanagedObjectsDataSource is the data source implementation.
+type unmanagedObjectsDataSource struct {
+	client    *bastionzero.Client
+	marker    *ownership.Marker
+	listCache *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client, ownership
+// marker, and list cache to the data source.
+func (d *unmanagedObjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.marker = providerData.OwnershipMarker
+	d.listCache = providerData.ListCache
+}
+
+// Metadata returns the unmanaged objects data source type name.
+func (d *unmanagedObjectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmanaged_objects"
+}
+
+// Schema defines the schema for the unmanaged objects data source.
+func (d *unmanagedObjectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get a list of the environments, policies, and Db targets in your BastionZero organization that are not managed by Terraform. " +
+			"An environment or policy is managed by Terraform if its description is stamped with the provider's `ownership_marker`. " +
+			"Db targets do not have a description and cannot be stamped, so the Db targets in unmanaged environments are listed instead.",
+		Attributes: map[string]schema.Attribute{
+			"ownership_marker": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ownership marker that managed objects are stamped with.",
+			},
+			"environments": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of environments whose description is not stamped with the ownership marker.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The environment's unique ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The environment's name.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The environment's description.",
+						},
+					},
+				},
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of policies of any type whose description is not stamped with the ownership marker.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The policy's unique ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The policy's name.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The policy's type (e.g. `TargetConnect`).",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The policy's description.",
+						},
+					},
+				},
+			},
+			"db_targets_in_unmanaged_environments": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "List of Db targets whose environment is not stamped with the ownership marker. " +
+					"A Db target in a managed environment is not listed even if it was created outside of Terraform.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The target's unique ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The target's name.",
+						},
+						"environment_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The target's environment's ID.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *unmanagedObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := telemetry.StartDataSourceSpan(ctx, "bastionzero_unmanaged_objects")
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	if d.marker == nil {
+		resp.Diagnostics.AddError(
+			"Missing BastionZero Ownership Marker",
+			"Unmanaged objects can only be found if managed objects are stamped with an ownership marker. "+
+				"Set ownership_marker in the provider configuration.",
+		)
+		return
+	}
+	ctx = tflog.SetField(ctx, "ownership_marker", d.marker.String())
+	ctx = listcache.NewContext(ctx, d.listCache)
+
+	// Environments
+	tflog.Debug(ctx, "Querying for environments")
+	envs, err := listcache.List(ctx, listcache.KindEnvironment, "", func(ctx context.Context) ([]environments.Environment, error) {
+		envs, _, err := d.client.Environments.ListEnvironments(ctx)
+		return envs, err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list environments",
+			err.Error(),
+		)
+		return
+	}
+
+	unmanagedEnvIDs := make(map[string]bool)
+	var unmanagedEnvs []unmanagedEnvironmentModel
+	for _, env := range envs {
+		if d.marker.IsStamped(env.Description) {
+			continue
+		}
+		unmanagedEnvIDs[env.ID] = true
+		unmanagedEnvs = append(unmanagedEnvs, unmanagedEnvironmentModel{
+			ID:          types.StringValue(env.ID),
+			Name:        types.StringValue(env.Name),
+			Description: types.StringValue(env.Description),
+		})
+	}
+	sort.Slice(unmanagedEnvs, func(i, j int) bool {
+		return unmanagedEnvs[i].Name.ValueString() < unmanagedEnvs[j].Name.ValueString()
+	})
+
+	// Policies
+	tflog.Debug(ctx, "Querying for policies")
+	allPolicies, err := listAllPolicies(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list policies",
+			err.Error(),
+		)
+		return
+	}
+
+	var unmanagedPolicies []unmanagedPolicyModel
+	for _, p := range allPolicies {
+		if d.marker.IsStamped(p.GetDescription()) {
+			continue
+		}
+		unmanagedPolicies = append(unmanagedPolicies, unmanagedPolicyModel{
+			ID:          types.StringValue(p.GetID()),
+			Name:        types.StringValue(p.GetName()),
+			Type:        types.StringValue(string(p.GetPolicyType())),
+			Description: types.StringValue(p.GetDescription()),
+		})
+	}
+	sort.Slice(unmanagedPolicies, func(i, j int) bool {
+		if unmanagedPolicies[i].Type.ValueString() != unmanagedPolicies[j].Type.ValueString() {
+			return unmanagedPolicies[i].Type.ValueString() < unmanagedPolicies[j].Type.ValueString()
+		}
+		return unmanagedPolicies[i].Name.ValueString() < unmanagedPolicies[j].Name.ValueString()
+	})
+
+	// Db targets cannot be stamped, so report the ones in unmanaged
+	// environments
+	tflog.Debug(ctx, "Querying for db targets")
+	dbTargets, err := listcache.List(ctx, listcache.KindDbTarget, "", func(ctx context.Context) ([]targets.DatabaseTarget, error) {
+		dbTargets, _, err := d.client.Targets.ListDatabaseTargets(ctx)
+		return dbTargets, err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list db targets",
+			err.Error(),
+		)
+		return
+	}
+
+	var unmanagedDbTargets []unmanagedDbTargetModel
+	for _, t := range dbTargets {
+		if !unmanagedEnvIDs[t.EnvironmentID] {
+			continue
+		}
+		unmanagedDbTargets = append(unmanagedDbTargets, unmanagedDbTargetModel{
+			ID:            types.StringValue(t.ID),
+			Name:          types.StringValue(t.Name),
+			EnvironmentID: types.StringValue(t.EnvironmentID),
+		})
+	}
+	sort.Slice(unmanagedDbTargets, func(i, j int) bool {
+		return unmanagedDbTargets[i].Name.ValueString() < unmanagedDbTargets[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, "Found unmanaged objects", map[string]any{
+		"environments":                         len(unmanagedEnvs),
+		"policies":                             len(unmanagedPolicies),
+		"db_targets_in_unmanaged_environments": len(unmanagedDbTargets),
+	})
+
+	state := unmanagedObjectsModel{Marker: types.StringValue(d.marker.String())}
+	var listDiags diag.Diagnostics
+	state.Environments, listDiags = types.ListValueFrom(ctx, objectType[unmanagedEnvironmentModel](ctx), unmanagedEnvs)
+	resp.Diagnostics.Append(listDiags...)
+	state.Policies, listDiags = types.ListValueFrom(ctx, objectType[unmanagedPolicyModel](ctx), unmanagedPolicies)
+	resp.Diagnostics.Append(listDiags...)
+	state.DbTargets, listDiags = types.ListValueFrom(ctx, objectType[unmanagedDbTargetModel](ctx), unmanagedDbTargets)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// objectType returns the object type of the model T.
+func objectType[T any](ctx context.Context) types.ObjectType {
+	attributeTypes, _ := internal.AttributeTypes[T](ctx)
+	return types.ObjectType{AttrTypes: attributeTypes}
+}
+
+// unfilteredPoliciesCacheKey is the list cache key the policy list data
+// sources use when they are not filtered by subjects or groups, so that their
+// results are shared with this data source.
+const unfilteredPoliciesCacheKey = "subjects=&groups="
+
+// listAllPolicies lists the policies of every type.
+func listAllPolicies(ctx context.Context, client *bastionzero.Client) ([]policies.PolicyInterface, error) {
+	var all []policies.PolicyInterface
+
+	targetConnectPolicies, err := listcache.List(ctx, listcache.KindTargetConnectPolicy, unfilteredPoliciesCacheKey, func(ctx context.Context) ([]policies.TargetConnectPolicy, error) {
+		policies, _, err := client.Policies.ListTargetConnectPolicies(ctx, &policies.ListPolicyOptions{})
+		return policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range targetConnectPolicies {
+		all = append(all, &targetConnectPolicies[i])
+	}
+
+	kubernetesPolicies, err := listcache.List(ctx, listcache.KindKubernetesPolicy, unfilteredPoliciesCacheKey, func(ctx context.Context) ([]policies.KubernetesPolicy, error) {
+		policies, _, err := client.Policies.ListKubernetesPolicies(ctx, &policies.ListPolicyOptions{})
+		return policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range kubernetesPolicies {
+		all = append(all, &kubernetesPolicies[i])
+	}
+
+	proxyPolicies, err := listcache.List(ctx, listcache.KindProxyPolicy, unfilteredPoliciesCacheKey, func(ctx context.Context) ([]policies.ProxyPolicy, error) {
+		policies, _, err := client.Policies.ListProxyPolicies(ctx, &policies.ListPolicyOptions{})
+		return policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range proxyPolicies {
+		all = append(all, &proxyPolicies[i])
+	}
+
+	sessionRecordingPolicies, err := listcache.List(ctx, listcache.KindSessionRecordingPolicy, unfilteredPoliciesCacheKey, func(ctx context.Context) ([]policies.SessionRecordingPolicy, error) {
+		policies, _, err := client.Policies.ListSessionRecordingPolicies(ctx, &policies.ListPolicyOptions{})
+		return policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessionRecordingPolicies {
+		all = append(all, &sessionRecordingPolicies[i])
+	}
+
+	jitPolicies, err := listcache.List(ctx, listcache.KindJITPolicy, unfilteredPoliciesCacheKey, func(ctx context.Context) ([]policies.JITPolicy, error) {
+		policies, _, err := client.Policies.ListJITPolicies(ctx, &policies.ListPolicyOptions{})
+		return policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range jitPolicies {
+		all = append(all, &jitPolicies[i])
+	}
+
+	return all, nil
+}
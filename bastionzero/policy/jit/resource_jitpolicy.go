@@ -9,6 +9,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +26,7 @@ var (
 	_ resource.Resource                = &jitPolicyResource{}
 	_ resource.ResourceWithConfigure   = &jitPolicyResource{}
 	_ resource.ResourceWithImportState = &jitPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &jitPolicyResource{}
 )
 
 func NewJITPolicyResource() resource.Resource {
@@ -28,7 +35,11 @@ func NewJITPolicyResource() resource.Resource {
 
 // jitPolicyResource is the resource implementation.
 type jitPolicyResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -39,17 +50,21 @@ func (r *jitPolicyResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the JIT policy resource type name.
@@ -67,6 +82,10 @@ func (r *jitPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest
 
 // Create creates the JIT policy resource and sets the initial Terraform state.
 func (r *jitPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_jit_policy", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindJITPolicy)
+
 	// Read Terraform plan data into the model
 	var plan JITPolicyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -74,6 +93,10 @@ func (r *jitPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// Stamp the ownership marker so the policy can be identified as managed
+	// by Terraform
+	plan.Description = r.marker.StampValue(plan.Description)
+
 	// Generate API request body from plan
 	p := ExpandJITPolicy(ctx, &plan)
 
@@ -93,13 +116,18 @@ func (r *jitPolicyResource) Create(ctx context.Context, req resource.CreateReque
 	tflog.Debug(ctx, "Created JIT policy")
 
 	SetJITPolicyAttributes(ctx, &plan, createResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_jit_policy", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the JIT policy Terraform state with the latest data.
 func (r *jitPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_jit_policy", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state JITPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -124,6 +152,7 @@ func (r *jitPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 	tflog.Debug(ctx, "Queried for JIT policy")
 
 	SetJITPolicyAttributes(ctx, &state, p, false)
+	state.Description = r.marker.StripValue(state.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -132,6 +161,10 @@ func (r *jitPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 // Update updates the JIT policy resource and sets the updated Terraform state
 // on success.
 func (r *jitPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_jit_policy", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindJITPolicy)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state JITPolicyModel
 
@@ -149,7 +182,7 @@ func (r *jitPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		modPolicy.Name = bastionzero.PtrTo(plan.Name.ValueString())
 	}
 	if !plan.Description.Equal(state.Description) {
-		modPolicy.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modPolicy.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.Subjects.Equal(state.Subjects) {
 		modPolicy.Subjects = bastionzero.PtrTo(policy.ExpandPolicySubjects(ctx, plan.Subjects))
@@ -179,14 +212,20 @@ func (r *jitPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	SetJITPolicyAttributes(ctx, &plan, updateResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_jit_policy", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the JIT policy resource and removes the Terraform state on
 // success.
 func (r *jitPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_jit_policy", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindJITPolicy)
+
 	// Retrieve values from state
 	var state JITPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -209,9 +248,17 @@ func (r *jitPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 
 	tflog.Debug(ctx, "Deleted JIT policy")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_jit_policy", state.ID.ValueString(), req.State)...)
 }
 
 func (r *jitPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it.
+func (r *jitPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("JIT policy", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure        = &kubernetesPolicyResource{}
 	_ resource.ResourceWithImportState      = &kubernetesPolicyResource{}
 	_ resource.ResourceWithConfigValidators = &kubernetesPolicyResource{}
+	_ resource.ResourceWithModifyPlan       = &kubernetesPolicyResource{}
 )
 
 func NewKubernetesPolicyResource() resource.Resource {
@@ -30,7 +37,11 @@ func NewKubernetesPolicyResource() resource.Resource {
 
 // kubernetesPolicyResource is the resource implementation.
 type kubernetesPolicyResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -41,17 +52,21 @@ func (r *kubernetesPolicyResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the Kubernetes policy resource type name.
@@ -69,6 +84,10 @@ func (r *kubernetesPolicyResource) Schema(ctx context.Context, _ resource.Schema
 
 // Create creates the Kubernetes policy resource and sets the initial Terraform state.
 func (r *kubernetesPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_kubernetes_policy", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindKubernetesPolicy)
+
 	// Read Terraform plan data into the model
 	var plan KubernetesPolicyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -76,6 +95,10 @@ func (r *kubernetesPolicyResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	// Stamp the ownership marker so the policy can be identified as managed
+	// by Terraform
+	plan.Description = r.marker.StampValue(plan.Description)
+
 	// Generate API request body from plan
 	p := ExpandKubernetesPolicy(ctx, &plan)
 
@@ -95,13 +118,18 @@ func (r *kubernetesPolicyResource) Create(ctx context.Context, req resource.Crea
 	tflog.Debug(ctx, "Created Kubernetes policy")
 
 	SetKubernetesPolicyAttributes(ctx, &plan, createResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_kubernetes_policy", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the Kubernetes policy Terraform state with the latest data.
 func (r *kubernetesPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_kubernetes_policy", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state KubernetesPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -126,6 +154,7 @@ func (r *kubernetesPolicyResource) Read(ctx context.Context, req resource.ReadRe
 	tflog.Debug(ctx, "Queried for Kubernetes policy")
 
 	SetKubernetesPolicyAttributes(ctx, &state, p, false)
+	state.Description = r.marker.StripValue(state.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -134,6 +163,10 @@ func (r *kubernetesPolicyResource) Read(ctx context.Context, req resource.ReadRe
 // Update updates the Kubernetes policy resource and sets the updated Terraform state
 // on success.
 func (r *kubernetesPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_kubernetes_policy", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindKubernetesPolicy)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state KubernetesPolicyModel
 
@@ -151,7 +184,7 @@ func (r *kubernetesPolicyResource) Update(ctx context.Context, req resource.Upda
 		modPolicy.Name = plan.Name.ValueString()
 	}
 	if !plan.Description.Equal(state.Description) {
-		modPolicy.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modPolicy.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.Subjects.Equal(state.Subjects) {
 		modPolicy.Subjects = bastionzero.PtrTo(policy.ExpandPolicySubjects(ctx, plan.Subjects))
@@ -183,14 +216,20 @@ func (r *kubernetesPolicyResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	SetKubernetesPolicyAttributes(ctx, &plan, updateResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_kubernetes_policy", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the Kubernetes policy resource and removes the Terraform state on
 // success.
 func (r *kubernetesPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_kubernetes_policy", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindKubernetesPolicy)
+
 	// Retrieve values from state
 	var state KubernetesPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -213,6 +252,7 @@ func (r *kubernetesPolicyResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	tflog.Debug(ctx, "Deleted Kubernetes policy")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_kubernetes_policy", state.ID.ValueString(), req.State)...)
 }
 
 func (r *kubernetesPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -230,3 +270,11 @@ func (r *kubernetesPolicyResource) ConfigValidators(ctx context.Context) []resou
 		),
 	}
 }
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it or if the policy applies to an environment that is not allowed.
+func (r *kubernetesPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("Kubernetes policy", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environments"), req, resp)
+}
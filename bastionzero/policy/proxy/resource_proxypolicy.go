@@ -9,6 +9,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure        = &proxyPolicyResource{}
 	_ resource.ResourceWithImportState      = &proxyPolicyResource{}
 	_ resource.ResourceWithConfigValidators = &proxyPolicyResource{}
+	_ resource.ResourceWithModifyPlan       = &proxyPolicyResource{}
 )
 
 func NewProxyPolicyResource() resource.Resource {
@@ -30,7 +37,11 @@ func NewProxyPolicyResource() resource.Resource {
 
 // proxyPolicyResource is the resource implementation.
 type proxyPolicyResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -41,17 +52,21 @@ func (r *proxyPolicyResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the proxy policy resource type name.
@@ -70,6 +85,10 @@ func (r *proxyPolicyResource) Schema(ctx context.Context, _ resource.SchemaReque
 // Create creates the proxy policy resource and sets the initial Terraform
 // state.
 func (r *proxyPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_proxy_policy", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindProxyPolicy)
+
 	// Read Terraform plan data into the model
 	var plan ProxyPolicyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -77,6 +96,10 @@ func (r *proxyPolicyResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Stamp the ownership marker so the policy can be identified as managed
+	// by Terraform
+	plan.Description = r.marker.StampValue(plan.Description)
+
 	// Generate API request body from plan
 	p := ExpandProxyPolicy(ctx, &plan)
 
@@ -96,13 +119,18 @@ func (r *proxyPolicyResource) Create(ctx context.Context, req resource.CreateReq
 	tflog.Debug(ctx, "Created proxy policy")
 
 	SetProxyPolicyAttributes(ctx, &plan, createResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_proxy_policy", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the proxy policy Terraform state with the latest data.
 func (r *proxyPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_proxy_policy", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state ProxyPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -127,6 +155,7 @@ func (r *proxyPolicyResource) Read(ctx context.Context, req resource.ReadRequest
 	tflog.Debug(ctx, "Queried for proxy policy")
 
 	SetProxyPolicyAttributes(ctx, &state, p, false)
+	state.Description = r.marker.StripValue(state.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -135,6 +164,10 @@ func (r *proxyPolicyResource) Read(ctx context.Context, req resource.ReadRequest
 // Update updates the proxy policy resource and sets the updated Terraform state
 // on success.
 func (r *proxyPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_proxy_policy", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindProxyPolicy)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state ProxyPolicyModel
 
@@ -152,7 +185,7 @@ func (r *proxyPolicyResource) Update(ctx context.Context, req resource.UpdateReq
 		modPolicy.Name = plan.Name.ValueString()
 	}
 	if !plan.Description.Equal(state.Description) {
-		modPolicy.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modPolicy.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.Subjects.Equal(state.Subjects) {
 		modPolicy.Subjects = bastionzero.PtrTo(policy.ExpandPolicySubjects(ctx, plan.Subjects))
@@ -181,14 +214,20 @@ func (r *proxyPolicyResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	SetProxyPolicyAttributes(ctx, &plan, updateResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_proxy_policy", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the proxy policy resource and removes the Terraform state on
 // success.
 func (r *proxyPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_proxy_policy", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindProxyPolicy)
+
 	// Retrieve values from state
 	var state ProxyPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -211,6 +250,7 @@ func (r *proxyPolicyResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	tflog.Debug(ctx, "Deleted proxy policy")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_proxy_policy", state.ID.ValueString(), req.State)...)
 }
 
 func (r *proxyPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -228,3 +268,11 @@ func (r *proxyPolicyResource) ConfigValidators(ctx context.Context) []resource.C
 		),
 	}
 }
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it or if the policy applies to an environment that is not allowed.
+func (r *proxyPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("proxy policy", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environments"), req, resp)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -32,9 +33,12 @@ func NewSessionRecordingPoliciesDataSource() datasource.DataSource {
 			ListAPIModels: func(ctx context.Context, listParameters policy.ListPolicyParametersModel, client *bastionzero.Client) ([]policies.SessionRecordingPolicy, error) {
 				subjectsFilter := strings.Join(internal.ExpandFrameworkStringSet(ctx, listParameters.Subjects), ",")
 				groupsFilter := strings.Join(internal.ExpandFrameworkStringSet(ctx, listParameters.Groups), ",")
+				cacheKey := "subjects=" + subjectsFilter + "&groups=" + groupsFilter
 
-				policies, _, err := client.Policies.ListSessionRecordingPolicies(ctx, &policies.ListPolicyOptions{Subjects: subjectsFilter, Groups: groupsFilter})
-				return policies, err
+				return listcache.List(ctx, listcache.KindSessionRecordingPolicy, cacheKey, func(ctx context.Context) ([]policies.SessionRecordingPolicy, error) {
+					policies, _, err := client.Policies.ListSessionRecordingPolicies(ctx, &policies.ListPolicyOptions{Subjects: subjectsFilter, Groups: groupsFilter})
+					return policies, err
+				})
 			},
 		})
 }
@@ -9,6 +9,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +26,7 @@ var (
 	_ resource.Resource                = &sessionRecordingPolicyResource{}
 	_ resource.ResourceWithConfigure   = &sessionRecordingPolicyResource{}
 	_ resource.ResourceWithImportState = &sessionRecordingPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &sessionRecordingPolicyResource{}
 )
 
 func NewSessionRecordingPolicyResource() resource.Resource {
@@ -28,7 +35,11 @@ func NewSessionRecordingPolicyResource() resource.Resource {
 
 // sessionRecordingPolicyResource is the resource implementation.
 type sessionRecordingPolicyResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -39,17 +50,21 @@ func (r *sessionRecordingPolicyResource) Configure(_ context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the session recording policy resource type name.
@@ -68,6 +83,10 @@ func (r *sessionRecordingPolicyResource) Schema(ctx context.Context, _ resource.
 // Create creates the session recording policy resource and sets the initial
 // Terraform state.
 func (r *sessionRecordingPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_sessionrecording_policy", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindSessionRecordingPolicy)
+
 	// Read Terraform plan data into the model
 	var plan SessionRecordingPolicyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -75,6 +94,10 @@ func (r *sessionRecordingPolicyResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	// Stamp the ownership marker so the policy can be identified as managed
+	// by Terraform
+	plan.Description = r.marker.StampValue(plan.Description)
+
 	// Generate API request body from plan
 	p := ExpandSessionRecordingPolicy(ctx, &plan)
 
@@ -94,14 +117,19 @@ func (r *sessionRecordingPolicyResource) Create(ctx context.Context, req resourc
 	tflog.Debug(ctx, "Created session recording policy")
 
 	SetSessionRecordingPolicyAttributes(ctx, &plan, createResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_sessionrecording_policy", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the session recording policy Terraform state with the latest
 // data.
 func (r *sessionRecordingPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_sessionrecording_policy", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state SessionRecordingPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -126,6 +154,7 @@ func (r *sessionRecordingPolicyResource) Read(ctx context.Context, req resource.
 	tflog.Debug(ctx, "Queried for session recording policy")
 
 	SetSessionRecordingPolicyAttributes(ctx, &state, p, false)
+	state.Description = r.marker.StripValue(state.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -134,6 +163,10 @@ func (r *sessionRecordingPolicyResource) Read(ctx context.Context, req resource.
 // Update updates the session recording policy resource and sets the updated
 // Terraform state on success.
 func (r *sessionRecordingPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_sessionrecording_policy", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindSessionRecordingPolicy)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state SessionRecordingPolicyModel
 
@@ -151,7 +184,7 @@ func (r *sessionRecordingPolicyResource) Update(ctx context.Context, req resourc
 		modPolicy.Name = plan.Name.ValueString()
 	}
 	if !plan.Description.Equal(state.Description) {
-		modPolicy.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modPolicy.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.Subjects.Equal(state.Subjects) {
 		modPolicy.Subjects = bastionzero.PtrTo(policy.ExpandPolicySubjects(ctx, plan.Subjects))
@@ -174,14 +207,20 @@ func (r *sessionRecordingPolicyResource) Update(ctx context.Context, req resourc
 	}
 
 	SetSessionRecordingPolicyAttributes(ctx, &plan, updateResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_sessionrecording_policy", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the session recording policy resource and removes the
 // Terraform state on success.
 func (r *sessionRecordingPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_sessionrecording_policy", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindSessionRecordingPolicy)
+
 	// Retrieve values from state
 	var state SessionRecordingPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -204,9 +243,17 @@ func (r *sessionRecordingPolicyResource) Delete(ctx context.Context, req resourc
 	}
 
 	tflog.Debug(ctx, "Deleted session recording policy")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_sessionrecording_policy", state.ID.ValueString(), req.State)...)
 }
 
 func (r *sessionRecordingPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it.
+func (r *sessionRecordingPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("session recording policy", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+}
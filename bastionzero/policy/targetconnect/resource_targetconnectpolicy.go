@@ -9,6 +9,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/policies"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure        = &targetConnectPolicyResource{}
 	_ resource.ResourceWithImportState      = &targetConnectPolicyResource{}
 	_ resource.ResourceWithConfigValidators = &targetConnectPolicyResource{}
+	_ resource.ResourceWithModifyPlan       = &targetConnectPolicyResource{}
 )
 
 func NewTargetConnectPolicyResource() resource.Resource {
@@ -30,7 +37,11 @@ func NewTargetConnectPolicyResource() resource.Resource {
 
 // targetConnectPolicyResource is the resource implementation.
 type targetConnectPolicyResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+	marker     *ownership.Marker
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -41,17 +52,21 @@ func (r *targetConnectPolicyResource) Configure(_ context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.marker = providerData.OwnershipMarker
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the target connect policy resource type name.
@@ -69,6 +84,10 @@ func (r *targetConnectPolicyResource) Schema(ctx context.Context, _ resource.Sch
 
 // Create creates the target connect policy resource and sets the initial Terraform state.
 func (r *targetConnectPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_targetconnect_policy", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindTargetConnectPolicy)
+
 	// Read Terraform plan data into the model
 	var plan TargetConnectPolicyModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -76,6 +95,10 @@ func (r *targetConnectPolicyResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	// Stamp the ownership marker so the policy can be identified as managed
+	// by Terraform
+	plan.Description = r.marker.StampValue(plan.Description)
+
 	// Generate API request body from plan
 	p := ExpandTargetConnectPolicy(ctx, &plan)
 
@@ -95,13 +118,18 @@ func (r *targetConnectPolicyResource) Create(ctx context.Context, req resource.C
 	tflog.Debug(ctx, "Created target connect policy")
 
 	SetTargetConnectPolicyAttributes(ctx, &plan, createResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_targetconnect_policy", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the target connect policy Terraform state with the latest data.
 func (r *targetConnectPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_targetconnect_policy", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state TargetConnectPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -126,6 +154,7 @@ func (r *targetConnectPolicyResource) Read(ctx context.Context, req resource.Rea
 	tflog.Debug(ctx, "Queried for target connect policy")
 
 	SetTargetConnectPolicyAttributes(ctx, &state, p, false)
+	state.Description = r.marker.StripValue(state.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -134,6 +163,10 @@ func (r *targetConnectPolicyResource) Read(ctx context.Context, req resource.Rea
 // Update updates the target connect policy resource and sets the updated Terraform state
 // on success.
 func (r *targetConnectPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_targetconnect_policy", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindTargetConnectPolicy)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state TargetConnectPolicyModel
 
@@ -151,7 +184,7 @@ func (r *targetConnectPolicyResource) Update(ctx context.Context, req resource.U
 		modPolicy.Name = plan.Name.ValueString()
 	}
 	if !plan.Description.Equal(state.Description) {
-		modPolicy.Description = bastionzero.PtrTo(plan.Description.ValueString())
+		modPolicy.Description = bastionzero.PtrTo(r.marker.Stamp(plan.Description.ValueString()))
 	}
 	if !plan.Subjects.Equal(state.Subjects) {
 		modPolicy.Subjects = bastionzero.PtrTo(policy.ExpandPolicySubjects(ctx, plan.Subjects))
@@ -183,14 +216,20 @@ func (r *targetConnectPolicyResource) Update(ctx context.Context, req resource.U
 	}
 
 	SetTargetConnectPolicyAttributes(ctx, &plan, updateResp, false)
+	plan.Description = r.marker.StripValue(plan.Description)
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_targetconnect_policy", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the target connect policy resource and removes the Terraform state on
 // success.
 func (r *targetConnectPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_targetconnect_policy", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindTargetConnectPolicy)
+
 	// Retrieve values from state
 	var state TargetConnectPolicyModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -213,6 +252,7 @@ func (r *targetConnectPolicyResource) Delete(ctx context.Context, req resource.D
 	}
 
 	tflog.Debug(ctx, "Deleted target connect policy")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_targetconnect_policy", state.ID.ValueString(), req.State)...)
 }
 
 func (r *targetConnectPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -230,3 +270,11 @@ func (r *targetConnectPolicyResource) ConfigValidators(ctx context.Context) []re
 		),
 	}
 }
+
+// ModifyPlan rejects the planned change if the provider's guardrails do not
+// allow it or if the policy applies to an environment that is not allowed.
+func (r *targetConnectPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("target connect policy", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environments"), req, resp)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/autodiscoveryscript"
@@ -22,18 +23,31 @@ import (
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target/dbtarget"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target/webtarget"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/user"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzauth"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzhttp"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure BastionZeroProvider satisfies various provider interfaces.
-var _ provider.Provider = &BastionZeroProvider{}
+var (
+	_ provider.Provider                     = &BastionZeroProvider{}
+	_ provider.ProviderWithConfigValidators = &BastionZeroProvider{}
+)
 
 // BastionZeroProvider defines the provider implementation.
 type BastionZeroProvider struct {
@@ -45,8 +59,32 @@ type BastionZeroProvider struct {
 
 // bastionzeroProviderModel describes the provider data model.
 type bastionzeroProviderModel struct {
-	Host      types.String `tfsdk:"api_endpoint"`
-	APISecret types.String `tfsdk:"api_secret"`
+	Host                  types.String  `tfsdk:"api_endpoint"`
+	APISecret             types.String  `tfsdk:"api_secret"`
+	Profile               types.String  `tfsdk:"profile"`
+	CredentialsFile       types.String  `tfsdk:"credentials_file"`
+	CredentialProcess     types.String  `tfsdk:"credential_process"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.String  `tfsdk:"retry_max_wait"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	CACertFile            types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM             types.String  `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	HTTPProxy             types.String  `tfsdk:"http_proxy"`
+	ClientCertFile        types.String  `tfsdk:"client_cert_file"`
+	ClientCertPEM         types.String  `tfsdk:"client_cert_pem"`
+	ClientKeyFile         types.String  `tfsdk:"client_key_file"`
+	ClientKeyPEM          types.String  `tfsdk:"client_key_pem"`
+	RequestTimeout        types.String  `tfsdk:"request_timeout"`
+	ReadOnly              types.Bool    `tfsdk:"read_only"`
+	ChangeWindows         types.List    `tfsdk:"change_windows"`
+	ExpectedOrgID         types.String  `tfsdk:"expected_organization_id"`
+	AllowedEnvironmentIDs types.Set     `tfsdk:"allowed_environment_ids"`
+	NamingRules           types.Object  `tfsdk:"naming_rules"`
+	OwnershipMarker       types.String  `tfsdk:"ownership_marker"`
+	AuditLogPath          types.String  `tfsdk:"audit_log_path"`
+	LogHTTPBodies         types.Bool    `tfsdk:"log_http_bodies"`
 }
 
 func (p *BastionZeroProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -64,14 +102,171 @@ func (p *BastionZeroProvider) Schema(ctx context.Context, req provider.SchemaReq
 				Optional: true,
 			},
 			"api_secret": schema.StringAttribute{
-				Description: "API secret used to authenticate API requests sent to BastionZero. This can also be specified using the `BASTIONZERO_API_SECRET` environment variable.",
+				Description: "API secret used to authenticate API requests sent to BastionZero. This can also be specified using the `BASTIONZERO_API_SECRET` environment variable. Conflicts with `credential_process`.",
+				Sensitive:   true,
+				Optional:    true,
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "Command to run to obtain the API secret, e.g. a wrapper around a secrets manager CLI. The command is not run by a shell; arguments containing whitespace can be quoted. " +
+					"It must print a JSON object with an `api_secret` and an optional `expires_at` RFC 3339 timestamp (e.g. `{\"api_secret\": \"...\", \"expires_at\": \"2023-01-02T15:04:05Z\"}`) to stdout. " +
+					"If `expires_at` is returned, the command is run again when the secret is about to expire. Conflicts with `api_secret`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of times an API request is retried when it is throttled (HTTP 429) or fails with a transient error (HTTP 502, 503, or 504). "+
+					"Requests that are not idempotent (e.g. creating an object) are only retried when throttled. Set to `0` to disable retries. Defaults to `%d`.", bzhttp.DefaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: fmt.Sprintf("Maximum time to wait between retries, as a Go duration string (e.g. `1m`). Retries back off exponentially up to this value. "+
+					"If the API responds with a `Retry-After` header that exceeds this value, the request is not retried. Defaults to `%s`.", bzhttp.DefaultRetryMaxWait),
+				Optional: true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of API requests sent per second, shared by all resources and data sources configured by this provider. Short bursts of up to one second's worth of requests are allowed. Defaults to unlimited.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, shared by all resources and data sources configured by this provider. Defaults to unlimited.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file containing PEM encoded CA certificates to trust when verifying the BastionZero API's certificate, in addition to the system's trusted CAs. Conflicts with `ca_cert_pem`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates to trust when verifying the BastionZero API's certificate, in addition to the system's trusted CAs. Conflicts with `ca_cert_file`.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Disables verification of the BastionZero API's TLS certificate. This is insecure and should only be used when testing against a local stand-in for the API. Defaults to `false`.",
+				Optional:    true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the proxy used for BastionZero API requests (e.g. `http://proxy.example.com:3128`). If unset, the proxy is read from the `HTTPS_PROXY`, `HTTP_PROXY`, and `NO_PROXY` environment variables.",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to a file containing the PEM encoded client certificate presented for mutual TLS. Requires a client key. Conflicts with `client_cert_pem`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate presented for mutual TLS. Requires a client key. Conflicts with `client_cert_file`.",
+				Optional:    true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to a file containing the PEM encoded private key of the client certificate. Requires a client certificate. Conflicts with `client_key_pem`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate. Requires a client certificate. Conflicts with `client_key_file`.",
+				Optional:    true,
 				Sensitive:   true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Overall time limit for a BastionZero API request, including any retries, as a Go duration string (e.g. `2m`). Defaults to no timeout.",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "If `true`, any plan that would create, update, or delete a BastionZero object fails. Data sources and refreshing existing resources still work. " +
+					"This can also be specified using the `BASTIONZERO_READ_ONLY` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"change_windows": schema.ListAttribute{
+				Description: "Periods of time during which BastionZero objects may be created, updated, or deleted. If set, any plan that would change a BastionZero object outside of every window fails. " +
+					"Each window is either a recurring weekly schedule of days, a time range, and an optional IANA time zone that defaults to UTC (e.g. `Mon-Fri 09:00-17:00 America/New_York`, `Sat 22:00-02:00`, or `* 01:00-03:00`), " +
+					"or a fixed range of two RFC 3339 timestamps separated by a slash (e.g. `2023-12-01T09:00:00Z/2023-12-01T17:00:00Z`).",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"expected_organization_id": schema.StringAttribute{
+				Description: "ID of the BastionZero organization the provider's credentials must belong to. If set, the provider verifies the organization when it is configured and fails if the credentials belong to a different organization. " +
+					"Use this to prevent applying a configuration with the wrong credentials (e.g. a staging configuration with production credentials).",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"allowed_environment_ids": schema.SetAttribute{
+				Description: "Set of IDs of the environments that resources may reference. If set, any plan that would create or update a target or policy (e.g. a `bastionzero_db_target`'s `environment_id` or a policy's `environments`) " +
+					"that references an environment not in this set fails.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"log_http_bodies": schema.BoolAttribute{
+				Description: "If `true`, the JSON bodies of BastionZero API requests and responses are included in the provider's debug logs (e.g. with `TF_LOG=DEBUG`) alongside each request's method, URL, status, and latency. " +
+					"The values of sensitive fields, such as API secrets, tokens, and the `script` returned by the `bastionzero_ad_bash` data source, are masked. Defaults to `false`.",
+				Optional: true,
+			},
+			"naming_rules": namingRulesAttribute(),
+			"audit_log_path": schema.StringAttribute{
+				Description: "Path of a local file that a record of every change made to a BastionZero environment, policy, or db target is appended to. " +
+					"Each change is written as one JSON line with the time, resource type, object ID, the attributes that changed, and the organization and authentication method (`api_secret` or `credential_process`) of the caller. " +
+					"Lines are hash-chained so that edited, reordered, or removed lines can be detected with `go run ./tools/verifyauditlog <path>`. " +
+					"Use a separate file for each provider configuration.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ownership_marker": schema.StringAttribute{
+				Description: "Marker appended to the descriptions of the BastionZero environments and policies this provider creates, or whose description it updates (e.g. `[managed-by:terraform]`). " +
+					"The marker is stripped when the description is read, so it never appears in plans. Required by the `bastionzero_unmanaged_objects` data source to tell managed objects apart from objects created by other means.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the credentials file to read `api_secret` and `api_endpoint` from. This can also be specified using the `BASTIONZERO_PROFILE` environment variable. " +
+					"Values from a selected profile take precedence over the `BASTIONZERO_API_SECRET` and `BASTIONZERO_HOST` environment variables, but not over `api_secret` and `api_endpoint`. " +
+					"If no profile is selected, the `default` profile is used (if it exists) for any value not set in the configuration or environment.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"credentials_file": schema.StringAttribute{
+				Description: "Path to the credentials file containing named profiles. This can also be specified using the `BASTIONZERO_CREDENTIALS_FILE` environment variable. Defaults to `~/.bastionzero/credentials`.",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 		},
 	}
 }
 
+func (p *BastionZeroProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("api_secret"),
+			path.MatchRoot("credential_process"),
+		),
+	}
+}
+
 func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	ctx = tflog.SetField(ctx, "bastionzero_provider_version", p.version)
 	tflog.Info(ctx, "Configuring BastionZero client")
@@ -105,16 +300,108 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown BastionZero Credentials Profile",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for the BastionZero credentials profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BASTIONZERO_PROFILE environment variable.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown BastionZero Credentials File",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for the BastionZero credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BASTIONZERO_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown BastionZero Credential Process",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for the BastionZero credential process. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown BastionZero Max Retries",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Unknown BastionZero Retry Max Wait",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for retry_max_wait. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown BastionZero Requests Per Second",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for requests_per_second. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown BastionZero Max Concurrent Requests",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for max_concurrent_requests. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.LogHTTPBodies.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("log_http_bodies"),
+			"Unknown BastionZero Log HTTP Bodies",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for log_http_bodies. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default values to environment variables, but override with Terraform
-	// configuration value if set.
+	// Values are resolved in the following order of precedence (highest
+	// first):
+	//
+	//  1. The api_endpoint and api_secret Terraform configuration values
+	//  2. The selected credentials profile (profile or BASTIONZERO_PROFILE)
+	//  3. The BASTIONZERO_HOST and BASTIONZERO_API_SECRET environment variables
+	//  4. The "default" credentials profile if no profile is selected
 
 	host := os.Getenv("BASTIONZERO_HOST")
 	apiSecret := os.Getenv("BASTIONZERO_API_SECRET")
 
+	profile, profileSelected, diags := loadCredentialsProfile(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if profile != nil {
+		if profileSelected || host == "" {
+			host = valueOrDefault(profile.APIEndpoint, host)
+		}
+		if profileSelected || apiSecret == "" {
+			apiSecret = valueOrDefault(profile.APISecret, apiSecret)
+		}
+	}
+
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
@@ -123,16 +410,22 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 		apiSecret = config.APISecret.ValueString()
 	}
 
+	// A configured credential process takes precedence over an API secret set
+	// via the environment or a credentials profile. Setting both it and
+	// api_secret in the configuration is rejected by ConfigValidators.
+	useCredentialProcess := !config.CredentialProcess.IsNull()
+
 	// If any of the expected configurations are missing, return errors with
 	// provider-specific guidance.
 
-	if apiSecret == "" {
+	if apiSecret == "" && !useCredentialProcess {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_secret"),
 			"Missing BastionZero API Secret",
 			"The provider cannot create the BastionZero API client as there is a missing or empty value for the BastionZero API secret. "+
-				"Set the api_secret value in the configuration or use the BASTIONZERO_API_SECRET environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the api_secret value in the configuration, use the BASTIONZERO_API_SECRET environment variable, configure credential_process, "+
+				"or select a profile (profile or BASTIONZERO_PROFILE) with an api_secret in the credentials file (credentials_file, BASTIONZERO_CREDENTIALS_FILE, or ~/.bastionzero/credentials). "+
+				"If any of these is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -140,6 +433,25 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
+	maxRetries := bzhttp.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxWait := bzhttp.DefaultRetryMaxWait
+	if !config.RetryMaxWait.IsNull() {
+		var err error
+		retryMaxWait, err = time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil || retryMaxWait <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid BastionZero Retry Max Wait",
+				fmt.Sprintf("retry_max_wait must be a positive duration such as \"30s\" or \"1m\", got: %q", config.RetryMaxWait.ValueString()),
+			)
+			return
+		}
+	}
+
 	// Always include user agent header
 	opts := []bastionzero.ClientOpt{bastionzero.WithUserAgent("terraform-provider-bastionzero/" + p.version)}
 	// If custom host specified, configure client with base URL
@@ -148,13 +460,104 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 	}
 
 	ctx = tflog.SetField(ctx, "bastionzero_host", host)
+
+	// authMethod is recorded in the audit log
+	authMethod := "api_secret"
+	// credential authenticates requests when not using a static API secret
+	var credential bzauth.Credential
+	// credentialSecrets returns the secrets obtained by credential so that they
+	// are masked in logs, including ones obtained after Configure returns
+	var credentialSecrets func() []string
+	if useCredentialProcess {
+		process, err := bzauth.NewCredentialProcess(config.CredentialProcess.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_process"),
+				"Invalid BastionZero Credential Process",
+				"The provider cannot create the BastionZero API client as the credential process command is invalid: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Running BastionZero credential process")
+		apiSecret, err = process.APISecret(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_process"),
+				"Unable to run BastionZero Credential Process",
+				"The provider cannot create the BastionZero API client as the credential process did not return an API secret: "+err.Error(),
+			)
+			return
+		}
+		// Re-run the credential process whenever the API secret expires
+		credential = process
+		credentialSecrets = process.Secrets
+
+		authMethod = "credential_process"
+	}
+
+	ctx = tflog.SetField(ctx, "bastionzero_auth_method", authMethod)
 	ctx = tflog.SetField(ctx, "bastionzero_api_secret", apiSecret)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "bastionzero_api_secret")
 
+	// Zero means unlimited
+	requestsPerSecond := config.RequestsPerSecond.ValueFloat64()
+	maxConcurrentRequests := int(config.MaxConcurrentRequests.ValueInt64())
+
+	baseTransport, diags := newBaseTransport(config)
+	resp.Diagnostics.Append(diags...)
+	requestTimeout, diags := parseRequestTimeout(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrails, diags := newGuardrails(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	marker, diags := newOwnershipMarker(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		tflog.Warn(ctx, "TLS certificate verification is disabled for BastionZero API requests")
+	}
+
+	// Build the transport from the innermost layer out. Retries are outermost
+	// (apart from tracing, which records a request and its retries as one
+	// span) so that each attempt is authenticated again, logged, and counts
+	// against the rate limit. The client, and therefore the rate limit, is
+	// shared by every resource and data source.
+	var transport http.RoundTripper = baseTransport
+	if credential != nil {
+		transport = &bzauth.Transport{Base: transport, Credential: credential}
+	}
+	loggingTransport := bzhttp.NewLoggingTransport(transport, config.LogHTTPBodies.ValueBool(), apiSecret)
+	loggingTransport.SecretsFunc = credentialSecrets
+	transport = loggingTransport
+	transport = bzhttp.NewRateLimitTransport(transport, requestsPerSecond, maxConcurrentRequests)
+	transport = bzhttp.NewRetryTransport(transport, maxRetries, retryMaxWait)
+	transport = bzhttp.NewTracingTransport(transport)
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+
+	ctx = tflog.SetField(ctx, "bastionzero_max_retries", maxRetries)
+	ctx = tflog.SetField(ctx, "bastionzero_retry_max_wait", retryMaxWait.String())
+	ctx = tflog.SetField(ctx, "bastionzero_requests_per_second", requestsPerSecond)
+	ctx = tflog.SetField(ctx, "bastionzero_max_concurrent_requests", maxConcurrentRequests)
+	ctx = tflog.SetField(ctx, "bastionzero_request_timeout", requestTimeout.String())
+	ctx = tflog.SetField(ctx, "bastionzero_insecure_skip_verify", config.InsecureSkipVerify.ValueBool())
+	ctx = tflog.SetField(ctx, "bastionzero_log_http_bodies", config.LogHTTPBodies.ValueBool())
+	ctx = tflog.SetField(ctx, "bastionzero_read_only", guardrails.ReadOnly)
+	ctx = tflog.SetField(ctx, "bastionzero_change_windows", len(guardrails.ChangeWindows))
+
 	tflog.Debug(ctx, "Creating BastionZero client")
 
 	// Create a new BastionZero client using the configuration values
-	client, err := bastionzero.NewFromAPISecret(http.DefaultClient, apiSecret, opts...)
+	client, err := bastionzero.NewFromAPISecret(httpClient, apiSecret, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create BastionZero API Client",
@@ -165,10 +568,31 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	// Make the BastionZero client available during DataSource and Resource type
-	// Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if !config.ExpectedOrgID.IsNull() {
+		resp.Diagnostics.Append(verifyOrganization(ctx, client, config.ExpectedOrgID.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	auditLog, diags := openAuditLog(ctx, client, config.AuditLogPath, authMethod)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the BastionZero client, guardrails, ownership marker, audit log,
+	// and list cache available during DataSource and Resource type Configure
+	// methods.
+	providerData := &providerdata.ProviderData{
+		Client:          client,
+		Guardrails:      guardrails,
+		OwnershipMarker: marker,
+		AuditLog:        auditLog,
+		ListCache:       listcache.New(listcache.DefaultTTL),
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 
 	tflog.Info(ctx, "Configured BastionZero client", map[string]any{"success": true})
 }
@@ -176,12 +600,17 @@ func (p *BastionZeroProvider) Configure(ctx context.Context, req provider.Config
 func (p *BastionZeroProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		environment.NewEnvironmentResource,
+		environment.NewEnvironmentTargetAttachmentResource,
 		targetconnect.NewTargetConnectPolicyResource,
 		kubernetes.NewKubernetesPolicyResource,
 		proxy.NewProxyPolicyResource,
 		sessionrecording.NewSessionRecordingPolicyResource,
 		jit.NewJITPolicyResource,
 		dbtarget.NewDbTargetResource,
+		webtarget.NewWebTargetResource,
+		dactarget.NewDacTargetResource,
+		bzerotarget.NewBzeroTargetResource,
+		clustertarget.NewClusterTargetResource,
 	}
 }
 
@@ -190,10 +619,13 @@ func (p *BastionZeroProvider) DataSources(ctx context.Context) []func() datasour
 		user.NewUserDataSource,
 		user.NewUsersDataSource,
 		organization.NewGroupsDataSource,
+		organization.NewUnmanagedObjectsDataSource,
+		organization.NewOrganizationDataSource,
 		serviceaccount.NewServiceAccountDataSource,
 		serviceaccount.NewServiceAccountsDataSource,
 		environment.NewEnvironmentDataSource,
 		environment.NewEnvironmentsDataSource,
+		environment.NewEnvironmentCleanupPreviewDataSource,
 		bzerotarget.NewBzeroTargetDataSource,
 		bzerotarget.NewBzeroTargetsDataSource,
 		clustertarget.NewClusterTargetDataSource,
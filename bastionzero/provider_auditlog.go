@@ -0,0 +1,59 @@
+package bastionzero
+
+import (
+	"context"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// openAuditLog opens the audit log at auditLogPath and records changes as made
+// by the organization the client's credentials belong to, authenticated with
+// authMethod. It returns nil if audit_log_path is not configured.
+func openAuditLog(ctx context.Context, client *bastionzero.Client, auditLogPath types.String, authMethod string) (*auditlog.Logger, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if auditLogPath.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unknown BastionZero Audit Log Path",
+			"The provider cannot be configured as there is an unknown configuration value for audit_log_path. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+	if auditLogPath.IsNull() {
+		return nil, diags
+	}
+
+	tflog.Debug(ctx, "Querying for BastionZero organization of the caller")
+	org, _, err := client.Organization.GetUserOrganization(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unable to Determine BastionZero Caller Organization",
+			"The provider could not query the organization of the configured BastionZero credentials to record in the audit log: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	auditLog, err := auditlog.Open(auditLogPath.ValueString(), auditlog.Caller{
+		OrganizationID:   org.ID,
+		OrganizationName: org.Name,
+		AuthMethod:       authMethod,
+	})
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unable to Open BastionZero Audit Log",
+			"The provider could not open the audit log: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return auditLog, diags
+}
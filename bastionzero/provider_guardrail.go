@@ -0,0 +1,271 @@
+package bastionzero
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// namingRulesModel describes the provider's naming_rules attribute.
+type namingRulesModel struct {
+	Pattern        types.String `tfsdk:"pattern"`
+	RequiredPrefix types.String `tfsdk:"required_prefix"`
+	MaxLength      types.Int64  `tfsdk:"max_length"`
+}
+
+func namingRulesAttribute() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		Description: "Conventions the names of BastionZero environments, policies, and targets (db, web, DAC, Bzero, and Cluster) managed by this provider must follow. " +
+			"Any plan that would create one of these objects, or rename it, with a name that does not follow every rule fails. Bzero and Cluster targets are adopted by the name their agent registered, so only renames of them are checked. Existing objects that are not renamed are not checked.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"pattern": schema.StringAttribute{
+				Description: "Regular expression (RE2 syntax) that names must match, e.g. `^tf-[a-z0-9-]+$`. Anchor the expression to match the entire name.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"required_prefix": schema.StringAttribute{
+				Description: "Prefix that names must start with, e.g. `tf-`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_length": schema.Int64Attribute{
+				Description: "Maximum number of characters in a name.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// newGuardrails creates the guardrails that restrict the changes resources are
+// allowed to plan according to the provider's configuration.
+func newGuardrails(ctx context.Context, config bastionzeroProviderModel) (*guardrail.Guardrails, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.ReadOnly.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown BastionZero Read-Only Setting",
+			"The provider cannot be configured as there is an unknown configuration value for read_only. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the BASTIONZERO_READ_ONLY environment variable.",
+		)
+	}
+	if config.ChangeWindows.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("change_windows"),
+			"Unknown BastionZero Change Windows",
+			"The provider cannot be configured as there is an unknown configuration value for change_windows. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if config.AllowedEnvironmentIDs.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("allowed_environment_ids"),
+			"Unknown BastionZero Allowed Environment IDs",
+			"The provider cannot be configured as there is an unknown configuration value for allowed_environment_ids. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if config.NamingRules.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("naming_rules"),
+			"Unknown BastionZero Naming Rules",
+			"The provider cannot be configured as there is an unknown configuration value for naming_rules. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if config.ExpectedOrgID.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("expected_organization_id"),
+			"Unknown BastionZero Expected Organization ID",
+			"The provider cannot be configured as there is an unknown configuration value for expected_organization_id. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	guardrails := new(guardrail.Guardrails)
+
+	if !config.ReadOnly.IsNull() {
+		guardrails.ReadOnly = config.ReadOnly.ValueBool()
+	} else if v := os.Getenv("BASTIONZERO_READ_ONLY"); v != "" {
+		readOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddError(
+				"Invalid BastionZero Read-Only Setting",
+				fmt.Sprintf("The BASTIONZERO_READ_ONLY environment variable must be a boolean such as \"true\" or \"false\", got: %q", v),
+			)
+			return nil, diags
+		}
+		guardrails.ReadOnly = readOnly
+	}
+
+	var changeWindows []string
+	diags.Append(config.ChangeWindows.ElementsAs(ctx, &changeWindows, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for i, s := range changeWindows {
+		w, err := guardrail.ParseChangeWindow(s)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("change_windows").AtListIndex(i),
+				"Invalid BastionZero Change Window",
+				"Change windows must be a recurring schedule such as \"Mon-Fri 09:00-17:00 America/New_York\" "+
+					"or a fixed range of RFC 3339 timestamps such as \"2023-12-01T09:00:00Z/2023-12-01T17:00:00Z\": "+err.Error(),
+			)
+			continue
+		}
+		guardrails.ChangeWindows = append(guardrails.ChangeWindows, w)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if !config.AllowedEnvironmentIDs.IsNull() {
+		guardrails.AllowedEnvironmentIDs = []string{}
+		diags.Append(config.AllowedEnvironmentIDs.ElementsAs(ctx, &guardrails.AllowedEnvironmentIDs, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		sort.Strings(guardrails.AllowedEnvironmentIDs)
+	}
+
+	if !config.NamingRules.IsNull() {
+		namingRules, namingRulesDiags := newNamingRules(ctx, config.NamingRules)
+		diags.Append(namingRulesDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		guardrails.NamingRules = namingRules
+	}
+
+	return guardrails, diags
+}
+
+// newNamingRules creates naming rules from the naming_rules configuration.
+func newNamingRules(ctx context.Context, config types.Object) (*guardrail.NamingRules, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rootPath := path.Root("naming_rules")
+
+	var model namingRulesModel
+	diags.Append(config.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if model.Pattern.IsUnknown() || model.RequiredPrefix.IsUnknown() || model.MaxLength.IsUnknown() {
+		diags.AddAttributeError(
+			rootPath,
+			"Unknown BastionZero Naming Rules",
+			"The provider cannot be configured as there is an unknown configuration value for naming_rules. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	rules := &guardrail.NamingRules{
+		RequiredPrefix: model.RequiredPrefix.ValueString(),
+		MaxLength:      int(model.MaxLength.ValueInt64()),
+	}
+
+	if !model.Pattern.IsNull() {
+		pattern, err := regexp.Compile(model.Pattern.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				rootPath.AtName("pattern"),
+				"Invalid BastionZero Naming Pattern",
+				fmt.Sprintf("pattern must be a valid regular expression, got: %q: %s", model.Pattern.ValueString(), err),
+			)
+			return nil, diags
+		}
+		rules.Pattern = pattern
+	}
+
+	return rules, diags
+}
+
+// verifyOrganization returns an error diagnostic if the caller does not belong
+// to the organization with ID expectedOrgID.
+func verifyOrganization(ctx context.Context, client *bastionzero.Client, expectedOrgID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Verifying BastionZero organization", map[string]any{"expected_organization_id": expectedOrgID})
+
+	org, _, err := client.Organization.GetUserOrganization(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("expected_organization_id"),
+			"Unable to verify BastionZero Organization",
+			"The provider could not query the organization of the configured BastionZero credentials: "+err.Error(),
+		)
+		return diags
+	}
+
+	if org.ID != expectedOrgID {
+		diags.AddAttributeError(
+			path.Root("expected_organization_id"),
+			"Unexpected BastionZero Organization",
+			fmt.Sprintf("The configured BastionZero credentials belong to organization %q (%s), but expected_organization_id is %q. "+
+				"Check that this configuration is being applied with the intended BastionZero credentials.", org.Name, org.ID, expectedOrgID),
+		)
+	}
+
+	return diags
+}
+
+// newOwnershipMarker creates the marker stamped on the descriptions of managed
+// objects. It returns nil if ownership_marker is not configured.
+func newOwnershipMarker(config bastionzeroProviderModel) (*ownership.Marker, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.OwnershipMarker.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("ownership_marker"),
+			"Unknown BastionZero Ownership Marker",
+			"The provider cannot be configured as there is an unknown configuration value for ownership_marker. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+	if config.OwnershipMarker.IsNull() {
+		return nil, diags
+	}
+
+	marker, err := ownership.NewMarker(config.OwnershipMarker.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("ownership_marker"),
+			"Invalid BastionZero Ownership Marker",
+			fmt.Sprintf("ownership_marker is invalid, got: %q: %s", config.OwnershipMarker.ValueString(), err),
+		)
+		return nil, diags
+	}
+
+	return marker, diags
+}
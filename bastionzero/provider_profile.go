@@ -0,0 +1,84 @@
+package bastionzero
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzauth"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// loadCredentialsProfile loads the credentials profile referenced by the
+// provider configuration. selected is true if the profile was explicitly
+// chosen via the profile attribute or the BASTIONZERO_PROFILE environment
+// variable.
+//
+// If no profile is selected, the default profile is returned if it exists. A
+// nil profile is returned if there is no default profile or credentials file.
+func loadCredentialsProfile(config bastionzeroProviderModel) (profile *bzauth.Profile, selected bool, diags diag.Diagnostics) {
+	profileName := os.Getenv("BASTIONZERO_PROFILE")
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+	}
+	selected = profileName != ""
+
+	credentialsFile := os.Getenv("BASTIONZERO_CREDENTIALS_FILE")
+	if !config.CredentialsFile.IsNull() {
+		credentialsFile = config.CredentialsFile.ValueString()
+	}
+	if credentialsFile == "" {
+		var err error
+		credentialsFile, err = bzauth.DefaultCredentialsFile()
+		if err != nil {
+			if selected {
+				diags.AddAttributeError(
+					path.Root("credentials_file"),
+					"Unable to locate BastionZero Credentials File",
+					"The provider cannot locate the default credentials file. Set the credentials_file value in the configuration or use the BASTIONZERO_CREDENTIALS_FILE environment variable.\n\n"+
+						"Error: "+err.Error(),
+				)
+			}
+			return nil, selected, diags
+		}
+	}
+
+	if !selected {
+		profile, err := bzauth.LoadProfile(credentialsFile, bzauth.DefaultProfileName)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, bzauth.ErrProfileNotFound) {
+			// The default profile is optional
+			return nil, false, diags
+		} else if err != nil {
+			diags.AddAttributeError(
+				path.Root("credentials_file"),
+				"Unable to load BastionZero Credentials Profile",
+				"The provider cannot read the default profile from the credentials file: "+err.Error(),
+			)
+			return nil, false, diags
+		}
+		return profile, false, diags
+	}
+
+	profile, err := bzauth.LoadProfile(credentialsFile, profileName)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unable to load BastionZero Credentials Profile",
+			"The provider cannot create the BastionZero API client as the selected credentials profile could not be loaded. "+
+				"Ensure the profile exists in the credentials file or unset the profile value and the BASTIONZERO_PROFILE environment variable.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return nil, true, diags
+	}
+
+	return profile, true, diags
+}
+
+// valueOrDefault returns v if it is not empty, otherwise def.
+func valueOrDefault(v, def string) string {
+	if v != "" {
+		return v
+	}
+	return def
+}
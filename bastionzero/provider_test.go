@@ -1,6 +1,9 @@
 package bastionzero_test
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -9,9 +12,11 @@ import (
 )
 
 func TestProviderConfig_InvalidAPISecret(t *testing.T) {
-	// Clear env-var if set
+	// Clear env-var if set and ignore any local credentials file
 	closer := acctest.SetEnvironmentVariables(map[string]string{
-		"BASTIONZERO_API_SECRET": "",
+		"BASTIONZERO_API_SECRET":       "",
+		"BASTIONZERO_PROFILE":          "",
+		"BASTIONZERO_CREDENTIALS_FILE": filepath.Join(t.TempDir(), "credentials"),
 	})
 	t.Cleanup(closer)
 
@@ -43,3 +48,382 @@ func TestProviderConfig_InvalidAPISecret(t *testing.T) {
 		},
 	})
 }
+
+func TestProviderConfig_Profile(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	err := os.WriteFile(credentialsFile, []byte("[staging]\napi_secret = foo\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clear env-vars if set
+	closer := acctest.SetEnvironmentVariables(map[string]string{
+		"BASTIONZERO_API_SECRET":       "",
+		"BASTIONZERO_PROFILE":          "",
+		"BASTIONZERO_CREDENTIALS_FILE": "",
+	})
+	t.Cleanup(closer)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Profile does not exist
+				Config: fmt.Sprintf(`
+					provider "bastionzero" {
+						profile          = "prod"
+						credentials_file = %[1]q
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`, credentialsFile),
+				ExpectError: regexp.MustCompile(`Unable to load BastionZero Credentials Profile`),
+			},
+			{
+				// Secret is read from the profile
+				Config: fmt.Sprintf(`
+					provider "bastionzero" {
+						profile          = "staging"
+						credentials_file = %[1]q
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`, credentialsFile),
+				ExpectError: regexp.MustCompile(`Unable to create BastionZero API Client`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidCredentialProcess(t *testing.T) {
+	// Clear env-var if set
+	closer := acctest.SetEnvironmentVariables(map[string]string{
+		"BASTIONZERO_API_SECRET": "",
+	})
+	t.Cleanup(closer)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Conflicts with api_secret
+				Config: `
+					provider "bastionzero" {
+						api_secret         = "foo"
+						credential_process = "echo foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				// Command does not exist
+				Config: `
+					provider "bastionzero" {
+						credential_process = "/this/command/does/not/exist"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to run BastionZero Credential Process`),
+			},
+			{
+				// Unterminated quote
+				Config: `
+					provider "bastionzero" {
+						credential_process = "echo \"foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Credential Process`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidRetrySettings(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Negative max retries
+				Config: `
+					provider "bastionzero" {
+						api_secret  = "foo"
+						max_retries = -1
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+			{
+				// Bad duration
+				Config: `
+					provider "bastionzero" {
+						api_secret     = "foo"
+						retry_max_wait = "soon"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Retry Max Wait`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidRateLimitSettings(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret          = "foo"
+						requests_per_second = 0
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret              = "foo"
+						max_concurrent_requests = 0
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidTransportSettings(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Both CA sources
+				Config: `
+					provider "bastionzero" {
+						api_secret   = "foo"
+						ca_cert_file = "foo"
+						ca_cert_pem  = "foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				// Bad CA
+				Config: `
+					provider "bastionzero" {
+						api_secret  = "foo"
+						ca_cert_pem = "foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero TLS Configuration`),
+			},
+			{
+				// Missing CA file
+				Config: `
+					provider "bastionzero" {
+						api_secret   = "foo"
+						ca_cert_file = "/this/file/does/not/exist.pem"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to read BastionZero TLS File`),
+			},
+			{
+				// Client certificate without a key
+				Config: `
+					provider "bastionzero" {
+						api_secret      = "foo"
+						client_cert_pem = "foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Incomplete BastionZero Client Certificate Configuration`),
+			},
+			{
+				// Bad proxy
+				Config: `
+					provider "bastionzero" {
+						api_secret = "foo"
+						http_proxy = "proxy"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero HTTP Proxy`),
+			},
+			{
+				// Bad timeout
+				Config: `
+					provider "bastionzero" {
+						api_secret      = "foo"
+						request_timeout = "0s"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Request Timeout`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidGuardrailSettings(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Unknown day
+				Config: `
+					provider "bastionzero" {
+						api_secret     = "foo"
+						change_windows = ["Foo-Fri 09:00-17:00"]
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Change Window`),
+			},
+			{
+				// Unknown time zone
+				Config: `
+					provider "bastionzero" {
+						api_secret     = "foo"
+						change_windows = ["Mon-Fri 09:00-17:00 Not/AZone"]
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Change Window`),
+			},
+			{
+				// Fixed window ending before it starts
+				Config: `
+					provider "bastionzero" {
+						api_secret     = "foo"
+						change_windows = ["2023-12-01T17:00:00Z/2023-12-01T09:00:00Z"]
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Change Window`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidReadOnlyEnvironmentVariable(t *testing.T) {
+	closer := acctest.SetEnvironmentVariables(map[string]string{
+		"BASTIONZERO_READ_ONLY": "foo",
+	})
+	t.Cleanup(closer)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret = "foo"
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Read-Only Setting`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidNamingRules(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret   = "foo"
+						naming_rules = {
+							pattern = "^tf-[a-z"
+						}
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Naming Pattern`),
+			},
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret   = "foo"
+						naming_rules = {
+							max_length = 0
+						}
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+		},
+	})
+}
+
+func TestProviderConfig_InvalidOwnershipMarker(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "bastionzero" {
+						api_secret       = "foo"
+						ownership_marker = " [managed-by:terraform] "
+					}
+					resource "bastionzero_environment" "test" {
+						name = "test"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid BastionZero Ownership Marker`),
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package bastionzero
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzhttp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newBaseTransport creates the transport used to send requests to the
+// BastionZero API according to the provider's TLS and proxy configuration.
+func newBaseTransport(config bastionzeroProviderModel) (*http.Transport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, v := range map[string]attr.Value{
+		"ca_cert_file":         config.CACertFile,
+		"ca_cert_pem":          config.CACertPEM,
+		"insecure_skip_verify": config.InsecureSkipVerify,
+		"http_proxy":           config.HTTPProxy,
+		"client_cert_file":     config.ClientCertFile,
+		"client_cert_pem":      config.ClientCertPEM,
+		"client_key_file":      config.ClientKeyFile,
+		"client_key_pem":       config.ClientKeyPEM,
+	} {
+		if v.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Unknown BastionZero API Client Configuration",
+				fmt.Sprintf("The provider cannot create the BastionZero API client as there is an unknown configuration value for %s. ", name)+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var transportConfig bzhttp.TransportConfig
+	var ok bool
+
+	if transportConfig.CACertPEM, ok = readPEMAttribute(&diags, config.CACertFile, "ca_cert_file", config.CACertPEM); !ok {
+		return nil, diags
+	}
+	if transportConfig.ClientCertPEM, ok = readPEMAttribute(&diags, config.ClientCertFile, "client_cert_file", config.ClientCertPEM); !ok {
+		return nil, diags
+	}
+	if transportConfig.ClientKeyPEM, ok = readPEMAttribute(&diags, config.ClientKeyFile, "client_key_file", config.ClientKeyPEM); !ok {
+		return nil, diags
+	}
+
+	if (len(transportConfig.ClientCertPEM) > 0) != (len(transportConfig.ClientKeyPEM) > 0) {
+		diags.AddError(
+			"Incomplete BastionZero Client Certificate Configuration",
+			"The provider cannot create the BastionZero API client as mutual TLS requires both a client certificate (client_cert_file or client_cert_pem) "+
+				"and a client key (client_key_file or client_key_pem).",
+		)
+		return nil, diags
+	}
+
+	transportConfig.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+
+	if !config.HTTPProxy.IsNull() {
+		proxyURL, err := url.Parse(config.HTTPProxy.ValueString())
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			diags.AddAttributeError(
+				path.Root("http_proxy"),
+				"Invalid BastionZero HTTP Proxy",
+				fmt.Sprintf("http_proxy must be an absolute URL such as \"http://proxy.example.com:3128\", got: %q", config.HTTPProxy.ValueString()),
+			)
+			return nil, diags
+		}
+		transportConfig.ProxyURL = proxyURL
+	}
+
+	transport, err := bzhttp.NewTransport(transportConfig)
+	if err != nil {
+		diags.AddError(
+			"Invalid BastionZero TLS Configuration",
+			"The provider cannot create the BastionZero API client as the TLS configuration is invalid: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return transport, diags
+}
+
+// readPEMAttribute returns the contents of the file at fileValue if set,
+// otherwise the value of pemValue. ok is false if the file could not be read.
+func readPEMAttribute(diags *diag.Diagnostics, fileValue types.String, fileAttribute string, pemValue types.String) (contents []byte, ok bool) {
+	if fileValue.IsNull() {
+		return []byte(pemValue.ValueString()), true
+	}
+
+	contents, err := os.ReadFile(fileValue.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(fileAttribute),
+			"Unable to read BastionZero TLS File",
+			"The provider cannot create the BastionZero API client as the file could not be read: "+err.Error(),
+		)
+		return nil, false
+	}
+
+	return contents, true
+}
+
+// parseRequestTimeout returns the configured request_timeout. Zero means no
+// timeout.
+func parseRequestTimeout(config bastionzeroProviderModel) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.RequestTimeout.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Unknown BastionZero Request Timeout",
+			"The provider cannot create the BastionZero API client as there is an unknown configuration value for request_timeout. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return 0, diags
+	}
+
+	if config.RequestTimeout.IsNull() {
+		return 0, diags
+	}
+
+	timeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid BastionZero Request Timeout",
+			fmt.Sprintf("request_timeout must be a positive duration such as \"30s\" or \"2m\", got: %q", config.RequestTimeout.ValueString()),
+		)
+		return 0, diags
+	}
+
+	return timeout, diags
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/serviceaccounts"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -26,8 +27,10 @@ func NewServiceAccountsDataSource() datasource.DataSource {
 				"JSON Web Key Set (JWKS) URL. The headless authentication closely follows the OpenID Connect (OIDC) protocol.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]serviceaccounts.ServiceAccount, error) {
-			serviceAccounts, _, err := client.ServiceAccounts.ListServiceAccounts(ctx)
-			return serviceAccounts, err
+			return listcache.List(ctx, listcache.KindServiceAccount, "", func(ctx context.Context) ([]serviceaccounts.ServiceAccount, error) {
+				serviceAccounts, _, err := client.ServiceAccounts.ListServiceAccounts(ctx)
+				return serviceAccounts, err
+			})
 		},
 	})
 }
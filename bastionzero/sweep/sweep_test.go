@@ -9,7 +9,9 @@ import (
 	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy/proxy"
 	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy/sessionrecording"
 	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/policy/targetconnect"
+	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target/dactarget"
 	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target/dbtarget"
+	_ "github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target/webtarget"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
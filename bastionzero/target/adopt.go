@@ -0,0 +1,138 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// DefaultAdoptTimeout is how long the resources that adopt agent-registered
+// targets wait for the target to be found, if the practitioner does not
+// specify a timeout in the "timeouts" field.
+const DefaultAdoptTimeout = 15 * time.Minute
+
+// AdoptedTargetResourceAttributes returns a map of common TF attributes used by
+// the schemas of the resources that adopt agent-registered (bzero and cluster)
+// targets. The target is adopted by id or by name, and its name and
+// environment_id are managed once adopted.
+func AdoptedTargetResourceAttributes(targetType targettype.TargetType) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The target's unique ID. If set, the target with this ID is adopted. Changing this forces a new resource to be created.",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIf(
+					func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						// Only an explicitly configured ID selects a different
+						// target. A null config value means the target was
+						// adopted by name.
+						resp.RequiresReplace = !req.ConfigValue.IsNull()
+					},
+					"If the value of this attribute is configured and changes, Terraform will destroy and recreate the resource.",
+					"If the value of this attribute is configured and changes, Terraform will destroy and recreate the resource.",
+				),
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The target's name. If `id` is not set, the target with this name is adopted. Once adopted, changing this renames the target.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"type": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The target's type (constant value `%s`).", targetType),
+			Default:     stringdefault.StaticString(string(targetType)),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The target's status %s.", internal.PrettyOneOf(targetstatus.TargetStatusValues())),
+			Validators: []validator.String{
+				stringvalidator.OneOf(bastionzero.ToStringSlice(targetstatus.TargetStatusValues())...),
+			},
+		},
+		"environment_id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "The target's environment's ID. If set, the target is moved to this environment. If not set, the target is left in the environment it registered to.",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"last_agent_update": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The time this target's proxy agent last had a transition change in status %s. Null if there has not been a single transition change.", internal.PrettyRFC3339Timestamp()),
+		},
+		"agent_version": schema.StringAttribute{
+			Computed:    true,
+			Description: "The target's proxy agent's version.",
+		},
+		"region": schema.StringAttribute{
+			Computed:    true,
+			Description: "The BastionZero region that this target has connected to (follows same naming convention as AWS regions).",
+		},
+		"agent_public_key": schema.StringAttribute{
+			Computed:    true,
+			Description: "The target's proxy agent's public key.",
+		},
+		"control_channel": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "Information about the target's proxy agent's currently active control channel. Null if the target has no active control channel.",
+			Attributes: map[string]schema.Attribute{
+				"control_channel_id": schema.StringAttribute{
+					Computed:    true,
+					Description: "The control channel's unique ID.",
+				},
+				"connection_node_id": schema.StringAttribute{
+					Computed:    true,
+					Description: "The ID of the connection node that this control channel is connected to.",
+				},
+				"start_time": schema.StringAttribute{
+					Computed:    true,
+					Description: fmt.Sprintf("The time this control channel connected to the connection node %s.", internal.PrettyRFC3339Timestamp()),
+				},
+				"end_time": schema.StringAttribute{
+					Computed:    true,
+					Description: fmt.Sprintf("The time this control channel disconnected from the connection node %s. Null if the control channel is still active.", internal.PrettyRFC3339Timestamp()),
+				},
+			},
+		},
+	}
+}
+
+// AdoptedTargetMarkdownDescription returns the markdown description of a
+// resource that adopts agent-registered targets of type targetType.
+func AdoptedTargetMarkdownDescription(baseDescription string, targetType targettype.TargetType) string {
+	return fmt.Sprintf("%v"+
+		"\n\nSpecify at least one of `id` or `name` to select the %v target to adopt. When adopting by `name`, an error is triggered if more than one %v target is found. "+
+		"Creating this resource retries with exponential backoff (provide optional `timeouts.create` [duration](https://pkg.go.dev/time#ParseDuration) to control how long to retry. Defaults to 15 minutes.) until the %v target is found. "+
+		"This is useful if there is a chance the target does not exist yet (e.g. the target is in the process of registering to BastionZero).", baseDescription, targetType, targetType, targetType)
+}
@@ -2,12 +2,20 @@ package bzerotarget
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // bzeroTargetModel maps the bzero target schema data.
@@ -47,3 +55,75 @@ func makeBzeroTargetDataSourceSchema(opts *target.BaseTargetDataSourceAttributeO
 
 	return bzeroTargetAttributes
 }
+
+// bzeroTargetResourceModel maps the bzero target resource schema data.
+type bzeroTargetResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Status          types.String `tfsdk:"status"`
+	EnvironmentID   types.String `tfsdk:"environment_id"`
+	LastAgentUpdate types.String `tfsdk:"last_agent_update"`
+	AgentVersion    types.String `tfsdk:"agent_version"`
+	Region          types.String `tfsdk:"region"`
+	AgentPublicKey  types.String `tfsdk:"agent_public_key"`
+	ControlChannel  types.Object `tfsdk:"control_channel"`
+
+	DeleteOnDestroy types.Bool     `tfsdk:"delete_on_destroy"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (t *bzeroTargetResourceModel) SetID(value types.String)              { t.ID = value }
+func (t *bzeroTargetResourceModel) SetName(value types.String)            { t.Name = value }
+func (t *bzeroTargetResourceModel) SetType(value types.String)            { t.Type = value }
+func (t *bzeroTargetResourceModel) SetStatus(value types.String)          { t.Status = value }
+func (t *bzeroTargetResourceModel) SetEnvironmentID(value types.String)   { t.EnvironmentID = value }
+func (t *bzeroTargetResourceModel) SetLastAgentUpdate(value types.String) { t.LastAgentUpdate = value }
+func (t *bzeroTargetResourceModel) SetAgentVersion(value types.String)    { t.AgentVersion = value }
+func (t *bzeroTargetResourceModel) SetRegion(value types.String)          { t.Region = value }
+func (t *bzeroTargetResourceModel) SetAgentPublicKey(value types.String)  { t.AgentPublicKey = value }
+
+// setBzeroTargetResourceAttributes populates the TF resource schema data from a
+// bzero target API object.
+func setBzeroTargetResourceAttributes(ctx context.Context, schema *bzeroTargetResourceModel, bzeroTarget *targets.BzeroTarget) {
+	target.SetBaseTargetAttributes(ctx, schema, bzeroTarget)
+	schema.ControlChannel = target.FlattenControlChannelSummary(ctx, bzeroTarget.ControlChannel)
+}
+
+func makeBzeroTargetResourceSchema() map[string]resource_schema.Attribute {
+	bzeroTargetAttributes := target.AdoptedTargetResourceAttributes(targettype.Bzero)
+	bzeroTargetAttributes["delete_on_destroy"] = resource_schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "If `true`, the target is deleted from BastionZero when this resource is destroyed. If `false`, destroying this resource only stops managing the target, and the target remains registered. Defaults to `false`.",
+		Default:     booldefault.StaticBool(false),
+	}
+
+	return bzeroTargetAttributes
+}
+
+func readBzeroTarget(ctx context.Context, schema *bzeroTargetResourceModel, client *bastionzero.Client) (found bool, diags diag.Diagnostics) {
+	if schema.ID.IsUnknown() || schema.ID.IsNull() {
+		diags.AddError(
+			"Unexpected null ID in schema",
+			"Expected ID to be set. Please report this issue to the provider developers.",
+		)
+		return false, diags
+	}
+
+	// Get refreshed Bzero target value from BastionZero
+	tflog.Debug(ctx, "Querying for Bzero target")
+	bzeroTarget, _, err := client.Targets.GetBzeroTarget(ctx, schema.ID.ValueString())
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		return false, diags
+	} else if err != nil {
+		diags.AddError(
+			"Error reading Bzero target",
+			"Could not read Bzero target, unexpected error: "+err.Error())
+		return false, diags
+	}
+	tflog.Debug(ctx, "Queried for Bzero target")
+
+	setBzeroTargetResourceAttributes(ctx, schema, bzeroTarget)
+	return true, diags
+}
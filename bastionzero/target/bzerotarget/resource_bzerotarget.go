@@ -0,0 +1,319 @@
+package bzerotarget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzretry"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &bzeroTargetResource{}
+	_ resource.ResourceWithConfigure        = &bzeroTargetResource{}
+	_ resource.ResourceWithImportState      = &bzeroTargetResource{}
+	_ resource.ResourceWithModifyPlan       = &bzeroTargetResource{}
+	_ resource.ResourceWithConfigValidators = &bzeroTargetResource{}
+)
+
+func NewBzeroTargetResource() resource.Resource {
+	return &bzeroTargetResource{}
+}
+
+// bzeroTargetResource is the resource implementation.
+type bzeroTargetResource struct {
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client to the
+// resource.
+func (r *bzeroTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
+}
+
+// Metadata returns the Bzero target resource type name.
+func (r *bzeroTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bzero_target"
+}
+
+// Schema defines the schema for the Bzero target resource.
+func (r *bzeroTargetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := makeBzeroTargetResourceSchema()
+	attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{Create: true})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: target.AdoptedTargetMarkdownDescription(
+			"Adopts and manages a Bzero target that has registered itself to BastionZero. Bzero targets are Linux or Windows machines running the BastionZero agent.",
+			targettype.Bzero,
+		),
+		Attributes: attributes,
+	}
+}
+
+// Create adopts the Bzero target, applies any configured changes to it, and
+// sets the initial Terraform state.
+func (r *bzeroTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_bzero_target", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindBzeroTarget, listcache.KindEnvironment)
+	ctx = listcache.NewContext(ctx, r.listCache)
+
+	// Read Terraform plan data into the model
+	var plan bzeroTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, target.DefaultAdoptTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the target to adopt. It may still be registering, so retry until it
+	// is found
+	tflog.Debug(ctx, "Querying for Bzero target to adopt")
+	bzeroTarget, err := bzretry.Do(ctx, createTimeout, func(ctx context.Context) (*targets.BzeroTarget, error) {
+		if !plan.ID.IsUnknown() && !plan.ID.IsNull() {
+			bzeroTarget, _, err := r.client.Targets.GetBzeroTarget(ctx, plan.ID.ValueString())
+			return bzeroTarget, err
+		}
+
+		bzeroTargets, err := listcache.List(ctx, listcache.KindBzeroTarget, "", func(ctx context.Context) ([]targets.BzeroTarget, error) {
+			bzeroTargets, _, err := r.client.Targets.ListBzeroTargets(ctx)
+			return bzeroTargets, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return findBzeroTargetByName(bzeroTargets, plan.Name.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting Bzero target",
+			"Could not find Bzero target to adopt: "+err.Error(),
+		)
+		return
+	}
+	ctx = tflog.SetField(ctx, "bzero_target_id", bzeroTarget.ID)
+	tflog.Debug(ctx, "Found Bzero target to adopt")
+
+	// Apply the configured name and environment, if they differ from the
+	// adopted target's
+	modifyReq := new(targets.ModifyBzeroTargetRequest)
+	if !plan.Name.IsUnknown() && plan.Name.ValueString() != bzeroTarget.Name {
+		modifyReq.TargetName = bastionzero.PtrTo(plan.Name.ValueString())
+	}
+	if !plan.EnvironmentID.IsUnknown() && plan.EnvironmentID.ValueString() != bzeroTarget.EnvironmentID {
+		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	}
+	if modifyReq.TargetName != nil || modifyReq.EnvironmentID != nil {
+		bzeroTarget, _, err = r.client.Targets.ModifyBzeroTarget(ctx, bzeroTarget.ID, modifyReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting Bzero target",
+				"Could not update Bzero target, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	setBzeroTargetResourceAttributes(ctx, &plan, bzeroTarget)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_bzero_target", plan.ID.ValueString(), resp.State)...)
+}
+
+// Read refreshes the Bzero target Terraform state with the latest data.
+func (r *bzeroTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_bzero_target", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	// Read Terraform prior state data into the model
+	var state bzeroTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "bzero_target_id", state.ID.ValueString())
+
+	// Read Bzero target
+	found, diags := readBzeroTarget(ctx, &state, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// The next terraform plan will recreate the resource
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the Bzero target resource and sets the updated Terraform state
+// on success.
+func (r *bzeroTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_bzero_target", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindBzeroTarget, listcache.KindEnvironment)
+
+	// Read Terraform plan and current state data into the model
+	var plan, state bzeroTargetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "bzero_target_id", plan.ID.ValueString())
+
+	// Generate API request body from plan. Only include things in request that
+	// have changed between plan and current state
+	modifyReq := new(targets.ModifyBzeroTargetRequest)
+	if !plan.Name.Equal(state.Name) {
+		modifyReq.TargetName = bastionzero.PtrTo(plan.Name.ValueString())
+	}
+	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
+		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	}
+
+	if modifyReq.TargetName == nil && modifyReq.EnvironmentID == nil {
+		// Only attributes local to Terraform (e.g. delete_on_destroy) changed.
+		// Refresh the computed attributes
+		found, diags := readBzeroTarget(ctx, &plan, r.client)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !found {
+			resp.Diagnostics.AddError("Failed to find Bzero target during update", "")
+			return
+		}
+	} else {
+		// Update existing Bzero target
+		updateResp, _, err := r.client.Targets.ModifyBzeroTarget(ctx, plan.ID.ValueString(), modifyReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Bzero target",
+				"Could not update Bzero target, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		setBzeroTargetResourceAttributes(ctx, &plan, updateResp)
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_bzero_target", plan.ID.ValueString(), req.State, resp.State)...)
+}
+
+// Delete stops managing the Bzero target and, if delete_on_destroy is set,
+// deletes the target from BastionZero.
+func (r *bzeroTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_bzero_target", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindBzeroTarget, listcache.KindEnvironment)
+
+	// Retrieve values from state
+	var state bzeroTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "bzero_target_id", state.ID.ValueString())
+
+	if !state.DeleteOnDestroy.ValueBool() {
+		tflog.Info(ctx, "delete_on_destroy is false. Leaving Bzero target registered to BastionZero")
+		// Terraform no longer manages the target, so record that it was
+		// abandoned rather than deleted
+		resp.Diagnostics.Append(r.auditLog.RecordAbandon(ctx, "bastionzero_bzero_target", state.ID.ValueString(), req.State)...)
+		return
+	}
+
+	// Delete existing Bzero target
+	tflog.Debug(ctx, "Deleting Bzero target")
+	_, err := r.client.Targets.DeleteBzeroTarget(ctx, state.ID.ValueString())
+
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		// Return early without error if Bzero target is already deleted
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Bzero target",
+			"Could not delete Bzero target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted Bzero target")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_bzero_target", state.ID.ValueString(), req.State)...)
+}
+
+func (r *bzeroTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Imported targets are not deleted on destroy unless configured otherwise
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_on_destroy"), false)...)
+}
+
+func (r *bzeroTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("Bzero target", req, resp)
+	// The name of a target being adopted was chosen by its agent, so only
+	// renames are checked
+	r.guardrails.ValidateRename(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+}
+
+func (r *bzeroTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Validate at least one of the schema defined attributes named id and
+		// name has a known, non-null value.
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
@@ -0,0 +1,323 @@
+package bzerotarget_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBzeroTarget_AdoptByID(t *testing.T) {
+	ctx := context.Background()
+	resourceName := "bastionzero_bzero_target.test"
+	bzeroTarget := new(targets.BzeroTarget)
+	var adopted targets.BzeroTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBzeroTargetStillRegistered(bzeroTarget),
+		Steps: []resource.TestStep{
+			// Verify adopting leaves the target unchanged when name and
+			// environment_id are not configured
+			{
+				Config: testAccBzeroTargetConfigID(bzeroTarget.ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckBzeroTargetAttributes(&adopted, &expectedBzeroTarget{
+						EnvironmentID: &bzeroTarget.EnvironmentID,
+						Name:          &bzeroTarget.Name,
+					}),
+					testAccCheckResourceBzeroTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", bzeroTarget.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", bzeroTarget.Name),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", bzeroTarget.EnvironmentID),
+					resource.TestCheckResourceAttr(resourceName, "delete_on_destroy", "false"),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBzeroTarget_AdoptByName(t *testing.T) {
+	ctx := context.Background()
+	resourceName := "bastionzero_bzero_target.test"
+	bzeroTarget := new(targets.BzeroTarget)
+	var adopted targets.BzeroTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBzeroTargetStillRegistered(bzeroTarget),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBzeroTargetConfigName(bzeroTarget.Name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckResourceBzeroTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "id", bzeroTarget.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", bzeroTarget.Name),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", bzeroTarget.EnvironmentID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBzeroTarget_Name(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_bzero_target.test"
+	bzeroTarget := new(targets.BzeroTarget)
+	var adopted targets.BzeroTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	// Not parallel: the test renames a shared target
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBzeroTargetStillRegistered(bzeroTarget),
+		Steps: []resource.TestStep{
+			// Verify adopting renames the target
+			{
+				Config: testAccBzeroTargetConfigIDName(bzeroTarget.ID, rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckBzeroTargetAttributes(&adopted, &expectedBzeroTarget{
+						Name: &rName,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+				),
+			},
+			// Verify update name (restores the target's original name)
+			{
+				Config: testAccBzeroTargetConfigIDName(bzeroTarget.ID, bzeroTarget.Name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckBzeroTargetAttributes(&adopted, &expectedBzeroTarget{
+						Name: &bzeroTarget.Name,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", bzeroTarget.Name),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBzeroTarget_EnvironmentID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_bzero_target.test"
+	bzeroTarget := new(targets.BzeroTarget)
+	var adopted targets.BzeroTarget
+	var env environments.Environment
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	// Not parallel: the test moves a shared target
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckBzeroTargetStillRegistered(bzeroTarget),
+		Steps: []resource.TestStep{
+			// Verify adopting moves the target
+			{
+				Config: testAccBzeroTargetConfigEnvironment(rName, bzeroTarget.ID, "bastionzero_environment.test.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("bastionzero_environment.test", "id", func(value string) error {
+						env.ID = value
+						return nil
+					}),
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckBzeroTargetAttributes(&adopted, &expectedBzeroTarget{
+						EnvironmentID: &env.ID,
+					}),
+					resource.TestCheckResourceAttrPair(resourceName, "environment_id", "bastionzero_environment.test", "id"),
+				),
+			},
+			// Verify update environment (restores the target's original
+			// environment)
+			{
+				Config: testAccBzeroTargetConfigEnvironment(rName, bzeroTarget.ID, fmt.Sprintf("%q", bzeroTarget.EnvironmentID)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBzeroTargetExists(resourceName, &adopted),
+					testAccCheckBzeroTargetAttributes(&adopted, &expectedBzeroTarget{
+						EnvironmentID: &bzeroTarget.EnvironmentID,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", bzeroTarget.EnvironmentID),
+				),
+			},
+		},
+	})
+}
+
+func TestBzeroTarget_MissingIDAndName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "bastionzero_bzero_target" "test" {
+				}
+				`,
+				ExpectError: regexp.MustCompile(`At least one of these attributes must be configured`),
+			},
+		},
+	})
+}
+
+func TestBzeroTarget_InvalidID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccBzeroTargetConfigID("bad-id"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestBzeroTarget_InvalidName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Empty name not permitted
+				Config:      testAccBzeroTargetConfigName(""),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func TestBzeroTarget_InvalidEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config: fmt.Sprintf(`
+				resource "bastionzero_bzero_target" "test" {
+				  id = %[1]q
+				  environment_id = "bad-id"
+				}
+				`, uuid.New().String()),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func testAccBzeroTargetConfigID(id string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_bzero_target" "test" {
+  id = %[1]q
+}
+`, id)
+}
+
+func testAccBzeroTargetConfigName(name string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_bzero_target" "test" {
+  name = %[1]q
+}
+`, name)
+}
+
+func testAccBzeroTargetConfigIDName(id string, name string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_bzero_target" "test" {
+  id = %[1]q
+  name = %[2]q
+}
+`, id, name)
+}
+
+// testAccBzeroTargetConfigEnvironment adopts the target with ID id and sets its
+// environment_id to the Terraform expression envIDExpr.
+func testAccBzeroTargetConfigEnvironment(rName string, id string, envIDExpr string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_environment" "test" {
+  name = %[1]q
+}
+
+resource "bastionzero_bzero_target" "test" {
+  id = %[2]q
+  environment_id = %[3]s
+}
+`, rName, id, envIDExpr)
+}
+
+type expectedBzeroTarget struct {
+	EnvironmentID *string
+	Name          *string
+}
+
+func testAccCheckBzeroTargetAttributes(target *targets.BzeroTarget, expected *expectedBzeroTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if expected.EnvironmentID != nil && *expected.EnvironmentID != target.EnvironmentID {
+			return fmt.Errorf("Bad environment_id, expected \"%s\", got: %#v", *expected.EnvironmentID, target.EnvironmentID)
+		}
+		if expected.Name != nil && *expected.Name != target.Name {
+			return fmt.Errorf("Bad name, expected \"%s\", got: %#v", *expected.Name, target.Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckBzeroTargetExists(namedTFResource string, target *targets.BzeroTarget) resource.TestCheckFunc {
+	return acctest.CheckExistsAtBastionZero(namedTFResource, target, func(c *bastionzero.Client, ctx context.Context, id string) (*targets.BzeroTarget, *http.Response, error) {
+		return c.Targets.GetBzeroTarget(ctx, id)
+	})
+}
+
+func testAccCheckResourceBzeroTargetComputedAttr(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(acctest.UUIDV4RegexPattern)),
+		resource.TestCheckResourceAttrSet(resourceName, "agent_public_key"),
+		resource.TestCheckResourceAttrSet(resourceName, "agent_version"),
+		resource.TestCheckResourceAttrSet(resourceName, "region"),
+		acctest.CheckResourceAttrIsOneOf(resourceName, "status", bastionzero.ToStringSlice(targetstatus.TargetStatusValues())),
+		resource.TestCheckResourceAttr(resourceName, "type", string(targettype.Bzero)),
+	)
+}
+
+// testAccCheckBzeroTargetStillRegistered checks that destroying a resource
+// with delete_on_destroy unset leaves the adopted target registered.
+func testAccCheckBzeroTargetStillRegistered(bzeroTarget *targets.BzeroTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, _, err := acctest.APIClient.Targets.GetBzeroTarget(context.Background(), bzeroTarget.ID); err != nil {
+			return fmt.Errorf("expected Bzero target %s to still be registered: %w", bzeroTarget.ID, err)
+		}
+		return nil
+	}
+}
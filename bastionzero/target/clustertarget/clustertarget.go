@@ -2,14 +2,21 @@ package clustertarget
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // clusterTargetModel maps the cluster target schema data.
@@ -56,3 +63,77 @@ func makeClusterTargetDataSourceSchema(opts *target.BaseTargetDataSourceAttribut
 
 	return clusterTargetAttributes
 }
+
+// clusterTargetResourceModel maps the cluster target resource schema data.
+type clusterTargetResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	Status            types.String `tfsdk:"status"`
+	EnvironmentID     types.String `tfsdk:"environment_id"`
+	LastAgentUpdate   types.String `tfsdk:"last_agent_update"`
+	AgentVersion      types.String `tfsdk:"agent_version"`
+	Region            types.String `tfsdk:"region"`
+	AgentPublicKey    types.String `tfsdk:"agent_public_key"`
+	ControlChannel    types.Object `tfsdk:"control_channel"`
+	ValidClusterUsers types.Set    `tfsdk:"valid_cluster_users"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (t *clusterTargetResourceModel) SetID(value types.String)            { t.ID = value }
+func (t *clusterTargetResourceModel) SetName(value types.String)          { t.Name = value }
+func (t *clusterTargetResourceModel) SetType(value types.String)          { t.Type = value }
+func (t *clusterTargetResourceModel) SetStatus(value types.String)        { t.Status = value }
+func (t *clusterTargetResourceModel) SetEnvironmentID(value types.String) { t.EnvironmentID = value }
+func (t *clusterTargetResourceModel) SetLastAgentUpdate(value types.String) {
+	t.LastAgentUpdate = value
+}
+func (t *clusterTargetResourceModel) SetAgentVersion(value types.String)   { t.AgentVersion = value }
+func (t *clusterTargetResourceModel) SetRegion(value types.String)         { t.Region = value }
+func (t *clusterTargetResourceModel) SetAgentPublicKey(value types.String) { t.AgentPublicKey = value }
+
+// setClusterTargetResourceAttributes populates the TF resource schema data
+// from a cluster target API object.
+func setClusterTargetResourceAttributes(ctx context.Context, schema *clusterTargetResourceModel, clusterTarget *targets.ClusterTarget) {
+	target.SetBaseTargetAttributes(ctx, schema, clusterTarget)
+	schema.ControlChannel = target.FlattenControlChannelSummary(ctx, clusterTarget.ControlChannel)
+	schema.ValidClusterUsers = internal.FlattenFrameworkSet(ctx, types.StringType, clusterTarget.ValidClusterUsers, func(user string) attr.Value { return types.StringValue(user) })
+}
+
+func makeClusterTargetResourceSchema() map[string]resource_schema.Attribute {
+	clusterTargetAttributes := target.AdoptedTargetResourceAttributes(targettype.Cluster)
+	clusterTargetAttributes["valid_cluster_users"] = resource_schema.SetAttribute{
+		Computed:    true,
+		Description: "Set of Kubernetes user [subjects](https://kubernetes.io/docs/reference/access-authn-authz/rbac/#referring-to-subjects) that have been extracted from RoleBindings or ClusterRoleBindings defined in the cluster.",
+		ElementType: types.StringType,
+	}
+
+	return clusterTargetAttributes
+}
+
+func readClusterTarget(ctx context.Context, schema *clusterTargetResourceModel, client *bastionzero.Client) (found bool, diags diag.Diagnostics) {
+	if schema.ID.IsUnknown() || schema.ID.IsNull() {
+		diags.AddError(
+			"Unexpected null ID in schema",
+			"Expected ID to be set. Please report this issue to the provider developers.",
+		)
+		return false, diags
+	}
+
+	// Get refreshed cluster target value from BastionZero
+	tflog.Debug(ctx, "Querying for Cluster target")
+	clusterTarget, _, err := client.Targets.GetClusterTarget(ctx, schema.ID.ValueString())
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		return false, diags
+	} else if err != nil {
+		diags.AddError(
+			"Error reading Cluster target",
+			"Could not read Cluster target, unexpected error: "+err.Error())
+		return false, diags
+	}
+	tflog.Debug(ctx, "Queried for Cluster target")
+
+	setClusterTargetResourceAttributes(ctx, schema, clusterTarget)
+	return true, diags
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -66,7 +67,10 @@ func NewClusterTargetDataSource() datasource.DataSource {
 						} else if !tfModel.Name.IsNull() {
 							// Name provided. List targets and find target with
 							// specified name.
-							targets, _, err := client.Targets.ListClusterTargets(ctx)
+							targets, err := listcache.List(ctx, listcache.KindClusterTarget, "", func(ctx context.Context) ([]targets.ClusterTarget, error) {
+								targets, _, err := client.Targets.ListClusterTargets(ctx)
+								return targets, err
+							})
 							if err != nil {
 								return nil, err
 							}
@@ -7,6 +7,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -30,8 +31,10 @@ func NewClusterTargetsDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all Cluster targets in your BastionZero organization.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]targets.ClusterTarget, error) {
-			targets, _, err := client.Targets.ListClusterTargets(ctx)
-			return targets, err
+			return listcache.List(ctx, listcache.KindClusterTarget, "", func(ctx context.Context) ([]targets.ClusterTarget, error) {
+				targets, _, err := client.Targets.ListClusterTargets(ctx)
+				return targets, err
+			})
 		},
 	})
 }
@@ -0,0 +1,309 @@
+package clustertarget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzretry"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &clusterTargetResource{}
+	_ resource.ResourceWithConfigure        = &clusterTargetResource{}
+	_ resource.ResourceWithImportState      = &clusterTargetResource{}
+	_ resource.ResourceWithModifyPlan       = &clusterTargetResource{}
+	_ resource.ResourceWithConfigValidators = &clusterTargetResource{}
+)
+
+func NewClusterTargetResource() resource.Resource {
+	return &clusterTargetResource{}
+}
+
+// clusterTargetResource is the resource implementation.
+type clusterTargetResource struct {
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client to the
+// resource.
+func (r *clusterTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
+}
+
+// Metadata returns the Cluster target resource type name.
+func (r *clusterTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_target"
+}
+
+// Schema defines the schema for the Cluster target resource.
+func (r *clusterTargetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := makeClusterTargetResourceSchema()
+	attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{Create: true})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: target.AdoptedTargetMarkdownDescription(
+			"Adopts and manages a Cluster target that has registered itself to BastionZero. Cluster targets are Kubernetes clusters running the BastionZero agent, which is installed with the BastionZero Helm chart.",
+			targettype.Cluster,
+		),
+		Attributes: attributes,
+	}
+}
+
+// Create adopts the Cluster target, applies any configured changes to it, and
+// sets the initial Terraform state.
+func (r *clusterTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_cluster_target", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindClusterTarget, listcache.KindEnvironment)
+	ctx = listcache.NewContext(ctx, r.listCache)
+
+	// Read Terraform plan data into the model
+	var plan clusterTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, target.DefaultAdoptTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Find the target to adopt. It may still be registering, so retry until it
+	// is found
+	tflog.Debug(ctx, "Querying for Cluster target to adopt")
+	clusterTarget, err := bzretry.Do(ctx, createTimeout, func(ctx context.Context) (*targets.ClusterTarget, error) {
+		if !plan.ID.IsUnknown() && !plan.ID.IsNull() {
+			clusterTarget, _, err := r.client.Targets.GetClusterTarget(ctx, plan.ID.ValueString())
+			return clusterTarget, err
+		}
+
+		clusterTargets, err := listcache.List(ctx, listcache.KindClusterTarget, "", func(ctx context.Context) ([]targets.ClusterTarget, error) {
+			clusterTargets, _, err := r.client.Targets.ListClusterTargets(ctx)
+			return clusterTargets, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return findClusterTargetByName(clusterTargets, plan.Name.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting Cluster target",
+			"Could not find Cluster target to adopt: "+err.Error(),
+		)
+		return
+	}
+	ctx = tflog.SetField(ctx, "cluster_target_id", clusterTarget.ID)
+	tflog.Debug(ctx, "Found Cluster target to adopt")
+
+	// Apply the configured name and environment, if they differ from the
+	// adopted target's
+	modifyReq := new(targets.ModifyClusterTargetRequest)
+	if !plan.Name.IsUnknown() && plan.Name.ValueString() != clusterTarget.Name {
+		modifyReq.TargetName = bastionzero.PtrTo(plan.Name.ValueString())
+	}
+	if !plan.EnvironmentID.IsUnknown() && plan.EnvironmentID.ValueString() != clusterTarget.EnvironmentID {
+		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	}
+	if modifyReq.TargetName != nil || modifyReq.EnvironmentID != nil {
+		clusterTarget, _, err = r.client.Targets.ModifyClusterTarget(ctx, clusterTarget.ID, modifyReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting Cluster target",
+				"Could not update Cluster target, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	setClusterTargetResourceAttributes(ctx, &plan, clusterTarget)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_cluster_target", plan.ID.ValueString(), resp.State)...)
+}
+
+// Read refreshes the Cluster target Terraform state with the latest data.
+func (r *clusterTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_cluster_target", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	// Read Terraform prior state data into the model
+	var state clusterTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "cluster_target_id", state.ID.ValueString())
+
+	// Read Cluster target
+	found, diags := readClusterTarget(ctx, &state, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// The next terraform plan will recreate the resource
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the Cluster target resource and sets the updated Terraform state
+// on success.
+func (r *clusterTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_cluster_target", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindClusterTarget, listcache.KindEnvironment)
+
+	// Read Terraform plan and current state data into the model
+	var plan, state clusterTargetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "cluster_target_id", plan.ID.ValueString())
+
+	// Generate API request body from plan. Only include things in request that
+	// have changed between plan and current state
+	modifyReq := new(targets.ModifyClusterTargetRequest)
+	if !plan.Name.Equal(state.Name) {
+		modifyReq.TargetName = bastionzero.PtrTo(plan.Name.ValueString())
+	}
+	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
+		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	}
+
+	if modifyReq.TargetName == nil && modifyReq.EnvironmentID == nil {
+		// Only attributes local to Terraform (e.g. timeouts) changed. Refresh
+		// the computed attributes
+		found, diags := readClusterTarget(ctx, &plan, r.client)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !found {
+			resp.Diagnostics.AddError("Failed to find Cluster target during update", "")
+			return
+		}
+	} else {
+		// Update existing Cluster target
+		updateResp, _, err := r.client.Targets.ModifyClusterTarget(ctx, plan.ID.ValueString(), modifyReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Cluster target",
+				"Could not update Cluster target, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		setClusterTargetResourceAttributes(ctx, &plan, updateResp)
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_cluster_target", plan.ID.ValueString(), req.State, resp.State)...)
+}
+
+// Delete deletes the Cluster target's registration from BastionZero and
+// removes the Terraform state on success.
+func (r *clusterTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_cluster_target", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindClusterTarget, listcache.KindEnvironment)
+
+	// Retrieve values from state
+	var state clusterTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "cluster_target_id", state.ID.ValueString())
+
+	// Delete existing Cluster target
+	tflog.Debug(ctx, "Deleting Cluster target")
+	_, err := r.client.Targets.DeleteClusterTarget(ctx, state.ID.ValueString())
+
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		// Return early without error if Cluster target is already deleted
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Cluster target",
+			"Could not delete Cluster target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted Cluster target")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_cluster_target", state.ID.ValueString(), req.State)...)
+}
+
+func (r *clusterTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *clusterTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("Cluster target", req, resp)
+	// The name of a target being adopted was chosen by its agent, so only
+	// renames are checked
+	r.guardrails.ValidateRename(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+}
+
+func (r *clusterTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Validate at least one of the schema defined attributes named id and
+		// name has a known, non-null value.
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
@@ -0,0 +1,234 @@
+package clustertarget_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// disposableClusterNameEnvVar names the environment variable that holds the
+// name of a Cluster target that the acceptance tests may adopt. Destroying a
+// bastionzero_cluster_target deletes the target's registration, so these tests
+// never adopt a Cluster target found by listing.
+const disposableClusterNameEnvVar = "BASTIONZERO_ACC_DISPOSABLE_CLUSTER_NAME"
+
+// disposableClusterNameOrSkip returns the name of the disposable Cluster target
+// to adopt. If it is not set, then the current test is skipped.
+func disposableClusterNameOrSkip(t *testing.T) string {
+	name := os.Getenv(disposableClusterNameEnvVar)
+	if name == "" {
+		t.Skipf("Skipping test as it deletes the adopted Cluster target. Set env '%s' to the name of a Cluster target that may be deleted to run it", disposableClusterNameEnvVar)
+	}
+	return name
+}
+
+func TestAccClusterTarget_Basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_cluster_target.test"
+	var clusterTarget targets.ClusterTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+	clusterName := disposableClusterNameOrSkip(t)
+
+	env := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterTargetDestroy,
+		Steps: []resource.TestStep{
+			// Verify adopting by name works
+			{
+				Config: testAccClusterTargetConfigName(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterTargetExists(resourceName, &clusterTarget),
+					testAccCheckClusterTargetAttributes(&clusterTarget, &expectedClusterTarget{
+						Name: &clusterName,
+					}),
+					testAccCheckResourceClusterTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only configured when adopting
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Verify update name and environment
+			{
+				Config: testAccClusterTargetConfigNameEnvironment(rName, env.ID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterTargetExists(resourceName, &clusterTarget),
+					testAccCheckClusterTargetAttributes(&clusterTarget, &expectedClusterTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+					}),
+					testAccCheckResourceClusterTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env.ID),
+				),
+			},
+		},
+	})
+}
+
+func TestClusterTarget_MissingIDAndName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "bastionzero_cluster_target" "test" {
+				}
+				`,
+				ExpectError: regexp.MustCompile(`At least one of these attributes must be configured`),
+			},
+		},
+	})
+}
+
+func TestClusterTarget_InvalidID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config: `
+				resource "bastionzero_cluster_target" "test" {
+				  id = "bad-id"
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestClusterTarget_InvalidName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Empty name not permitted
+				Config:      testAccClusterTargetConfigName(""),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func TestClusterTarget_InvalidEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccClusterTargetConfigNameEnvironment("foo", "bad-id"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestClusterTarget_InvalidTimeout(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Unparseable duration not permitted
+				Config: fmt.Sprintf(`
+				resource "bastionzero_cluster_target" "test" {
+				  id = %[1]q
+				  timeouts = {
+				    create = "foo"
+				  }
+				}
+				`, uuid.New().String()),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Time Duration`),
+			},
+		},
+	})
+}
+
+func testAccClusterTargetConfigName(name string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_cluster_target" "test" {
+  name = %[1]q
+  timeouts = {
+    create = "1m"
+  }
+}
+`, name)
+}
+
+func testAccClusterTargetConfigNameEnvironment(name string, envID string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_cluster_target" "test" {
+  name = %[1]q
+  environment_id = %[2]q
+}
+`, name, envID)
+}
+
+type expectedClusterTarget struct {
+	EnvironmentID *string
+	Name          *string
+}
+
+func testAccCheckClusterTargetAttributes(target *targets.ClusterTarget, expected *expectedClusterTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if expected.EnvironmentID != nil && *expected.EnvironmentID != target.EnvironmentID {
+			return fmt.Errorf("Bad environment_id, expected \"%s\", got: %#v", *expected.EnvironmentID, target.EnvironmentID)
+		}
+		if expected.Name != nil && *expected.Name != target.Name {
+			return fmt.Errorf("Bad name, expected \"%s\", got: %#v", *expected.Name, target.Name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckClusterTargetExists(namedTFResource string, target *targets.ClusterTarget) resource.TestCheckFunc {
+	return acctest.CheckExistsAtBastionZero(namedTFResource, target, func(c *bastionzero.Client, ctx context.Context, id string) (*targets.ClusterTarget, *http.Response, error) {
+		return c.Targets.GetClusterTarget(ctx, id)
+	})
+}
+
+func testAccCheckResourceClusterTargetComputedAttr(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(acctest.UUIDV4RegexPattern)),
+		resource.TestCheckResourceAttrSet(resourceName, "agent_public_key"),
+		resource.TestCheckResourceAttrSet(resourceName, "agent_version"),
+		resource.TestCheckResourceAttrSet(resourceName, "region"),
+		resource.TestCheckResourceAttrSet(resourceName, "valid_cluster_users.#"),
+		acctest.CheckResourceAttrIsOneOf(resourceName, "status", bastionzero.ToStringSlice(targetstatus.TargetStatusValues())),
+		resource.TestCheckResourceAttr(resourceName, "type", string(targettype.Cluster)),
+	)
+}
+
+func testAccCheckClusterTargetDestroy(s *terraform.State) error {
+	return acctest.CheckAllResourcesWithTypeDestroyed(
+		"bastionzero_cluster_target",
+		func(client *bastionzero.Client, ctx context.Context, id string) (*targets.ClusterTarget, *http.Response, error) {
+			return client.Targets.GetClusterTarget(ctx, id)
+		},
+	)(s)
+}
@@ -3,8 +3,10 @@ package dactarget
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/dacstatus"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
@@ -12,11 +14,18 @@ import (
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// dacTargetModel maps the DAC target schema data.
+// dacTargetModel maps the DAC target schema data. The DAC target data source
+// and resource share this model as their schemas have the same attributes.
 type dacTargetModel struct {
 	ID            types.String `tfsdk:"id"`
 	Name          types.String `tfsdk:"name"`
@@ -28,7 +37,7 @@ type dacTargetModel struct {
 	Status        types.String `tfsdk:"status"`
 }
 
-// setDacTargetAttributes populates the TF schema data from a web target API
+// setDacTargetAttributes populates the TF schema data from a DAC target API
 // object
 func setDacTargetAttributes(ctx context.Context, schema *dacTargetModel, dacTarget *targets.DynamicAccessConfiguration) {
 	schema.ID = types.StringValue(dacTarget.ID)
@@ -89,3 +98,91 @@ func makeDacTargetDataSourceSchema(opts *dacTargetDataSourceAttributeOptions) ma
 		},
 	}
 }
+
+func makeDacTargetResourceSchema() map[string]resource_schema.Attribute {
+	return map[string]resource_schema.Attribute{
+		"id": resource_schema.StringAttribute{
+			Computed:    true,
+			Description: "The DAC's unique ID.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": resource_schema.StringAttribute{
+			Required:    true,
+			Description: "The DAC's name.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"type": resource_schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The target's type (constant value `%s`).", targettype.DynamicAccessConfig),
+			Default:     stringdefault.StaticString(string(targettype.DynamicAccessConfig)),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"environment_id": resource_schema.StringAttribute{
+			Required:    true,
+			Description: "The DAC's environment's ID.",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+		},
+		"start_webhook": resource_schema.StringAttribute{
+			Required:    true,
+			Description: "URL for the dynamic access provisioning server's webhook that starts a new instance.",
+			Validators: []validator.String{
+				bzvalidator.ValidURL(),
+			},
+		},
+		"stop_webhook": resource_schema.StringAttribute{
+			Required:    true,
+			Description: "URL for the dynamic access provisioning server's webhook that stops a new instance.",
+			Validators: []validator.String{
+				bzvalidator.ValidURL(),
+			},
+		},
+		"health_webhook": resource_schema.StringAttribute{
+			Required:    true,
+			Description: "URL for the dynamic access provisioning server's webhook that does a health check.",
+			Validators: []validator.String{
+				bzvalidator.ValidURL(),
+			},
+		},
+		"status": resource_schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The DAC's status %s.", internal.PrettyOneOf(dacstatus.DynamicAccessConfigurationStatusValues())),
+			Validators: []validator.String{
+				stringvalidator.OneOf(bastionzero.ToStringSlice(dacstatus.DynamicAccessConfigurationStatusValues())...),
+			},
+		},
+	}
+}
+
+func readDacTarget(ctx context.Context, schema *dacTargetModel, client *bastionzero.Client) (found bool, diags diag.Diagnostics) {
+	if schema.ID.IsUnknown() || schema.ID.IsNull() {
+		diags.AddError(
+			"Unexpected null ID in schema",
+			"Expected ID to be set. Please report this issue to the provider developers.",
+		)
+		return false, diags
+	}
+
+	// Get refreshed DAC target value from BastionZero
+	tflog.Debug(ctx, "Querying for DAC target")
+	dacTarget, _, err := client.Targets.GetDynamicAccessConfiguration(ctx, schema.ID.ValueString())
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		return false, diags
+	} else if err != nil {
+		diags.AddError(
+			"Error reading DAC target",
+			"Could not read DAC target, unexpected error: "+err.Error())
+		return false, diags
+	}
+	tflog.Debug(ctx, "Queried for DAC target")
+
+	setDacTargetAttributes(ctx, schema, dacTarget)
+	return true, diags
+}
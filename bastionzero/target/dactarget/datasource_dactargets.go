@@ -6,6 +6,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -28,8 +29,10 @@ func NewDacTargetsDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all dynamic access configuration (DAC) targets in your BastionZero organization.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]targets.DynamicAccessConfiguration, error) {
-			targets, _, err := client.Targets.ListDynamicAccessConfigurations(ctx)
-			return targets, err
+			return listcache.List(ctx, listcache.KindDynamicAccessConfiguration, "", func(ctx context.Context) ([]targets.DynamicAccessConfiguration, error) {
+				targets, _, err := client.Targets.ListDynamicAccessConfigurations(ctx)
+				return targets, err
+			})
 		},
 	})
 }
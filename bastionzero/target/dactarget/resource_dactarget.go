@@ -0,0 +1,255 @@
+package dactarget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &dacTargetResource{}
+	_ resource.ResourceWithConfigure   = &dacTargetResource{}
+	_ resource.ResourceWithImportState = &dacTargetResource{}
+	_ resource.ResourceWithModifyPlan  = &dacTargetResource{}
+)
+
+func NewDacTargetResource() resource.Resource {
+	return &dacTargetResource{}
+}
+
+// dacTargetResource is the resource implementation.
+type dacTargetResource struct {
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client to the
+// resource.
+func (r *dacTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
+}
+
+// Metadata returns the DAC target resource type name.
+func (r *dacTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dac_target"
+}
+
+// Schema defines the schema for the DAC target resource.
+func (r *dacTargetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provides a BastionZero dynamic access configuration (DAC) target. A DAC target configures the webhooks BastionZero calls to provision dynamic access targets (DATs) on demand.",
+		Attributes:          makeDacTargetResourceSchema(),
+	}
+}
+
+// Create creates the DAC target resource and sets the initial Terraform state.
+func (r *dacTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_dac_target", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDynamicAccessConfiguration, listcache.KindEnvironment)
+
+	// Read Terraform plan data into the model
+	var plan dacTargetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	createReq := new(targets.CreateDynamicAccessConfigurationRequest)
+	createReq.Name = plan.Name.ValueString()
+	createReq.EnvironmentId = plan.EnvironmentID.ValueString()
+	createReq.StartWebhook = plan.StartWebhook.ValueString()
+	createReq.StopWebhook = plan.StopWebhook.ValueString()
+	createReq.HealthWebhook = plan.HealthWebhook.ValueString()
+
+	// Create new DAC target
+	tflog.Debug(ctx, "Creating DAC target")
+	createResp, _, err := r.client.Targets.CreateDynamicAccessConfiguration(ctx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating DAC target",
+			"Could not create DAC target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	ctx = tflog.SetField(ctx, "dac_target_id", createResp.ID)
+	tflog.Debug(ctx, "Created DAC target")
+	plan.ID = types.StringValue(createResp.ID)
+
+	// Query using the GET API to populate other attributes
+	found, diags := readDacTarget(ctx, &plan, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Failed to find DAC target after create", "")
+		return
+	}
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_dac_target", plan.ID.ValueString(), resp.State)...)
+}
+
+// Read refreshes the DAC target Terraform state with the latest data.
+func (r *dacTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_dac_target", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	// Read Terraform prior state data into the model
+	var state dacTargetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "dac_target_id", state.ID.ValueString())
+
+	// Read DAC target
+	found, diags := readDacTarget(ctx, &state, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// The next terraform plan will recreate the resource
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the DAC target resource and sets the updated Terraform state
+// on success.
+func (r *dacTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_dac_target", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDynamicAccessConfiguration, listcache.KindEnvironment)
+
+	// Read Terraform plan and current state data into the model
+	var plan, state dacTargetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "dac_target_id", plan.ID.ValueString())
+
+	// Generate API request body from plan. Only include things in request that
+	// have changed between plan and current state
+	modifyReq := new(targets.ModifyDynamicAccessConfigurationRequest)
+	if !plan.Name.Equal(state.Name) {
+		modifyReq.Name = bastionzero.PtrTo(plan.Name.ValueString())
+	}
+	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
+		modifyReq.EnvironmentId = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	}
+	if !plan.StartWebhook.Equal(state.StartWebhook) {
+		modifyReq.StartWebhook = bastionzero.PtrTo(plan.StartWebhook.ValueString())
+	}
+	if !plan.StopWebhook.Equal(state.StopWebhook) {
+		modifyReq.StopWebhook = bastionzero.PtrTo(plan.StopWebhook.ValueString())
+	}
+	if !plan.HealthWebhook.Equal(state.HealthWebhook) {
+		modifyReq.HealthWebhook = bastionzero.PtrTo(plan.HealthWebhook.ValueString())
+	}
+
+	// Update existing DAC target
+	updateResp, _, err := r.client.Targets.ModifyDynamicAccessConfiguration(ctx, plan.ID.ValueString(), modifyReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating DAC target",
+			"Could not update DAC target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	setDacTargetAttributes(ctx, &plan, updateResp)
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_dac_target", plan.ID.ValueString(), req.State, resp.State)...)
+}
+
+// Delete deletes the DAC target resource and removes the Terraform state on
+// success.
+func (r *dacTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_dac_target", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDynamicAccessConfiguration, listcache.KindEnvironment)
+
+	// Retrieve values from state
+	var state dacTargetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "dac_target_id", state.ID.ValueString())
+
+	// Delete existing DAC target
+	tflog.Debug(ctx, "Deleting DAC target")
+	_, err := r.client.Targets.DeleteDynamicAccessConfiguration(ctx, state.ID.ValueString())
+
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		// Return early without error if DAC target is already deleted
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DAC target",
+			"Could not delete DAC target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted DAC target")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_dac_target", state.ID.ValueString(), req.State)...)
+}
+
+func (r *dacTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *dacTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("DAC target", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+}
@@ -0,0 +1,357 @@
+package dactarget_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/dacstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const (
+	testStartWebhook  = "https://example.com/start"
+	testStopWebhook   = "https://example.com/stop"
+	testHealthWebhook = "https://example.com/health"
+)
+
+func TestAccDacTarget_Basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_dac_target.test"
+	var target targets.DynamicAccessConfiguration
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDacTargetDestroy,
+		Steps: []resource.TestStep{
+			// Verify create works for a config set with all required attributes
+			{
+				Config: testAccDacTargetConfigBasic(rName, env.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						StartWebhook:  bastionzero.PtrTo(testStartWebhook),
+						StopWebhook:   bastionzero.PtrTo(testStopWebhook),
+						HealthWebhook: bastionzero.PtrTo(testHealthWebhook),
+					}),
+					testAccCheckResourceDacTargetComputedAttr(resourceName),
+					// Check the state value we explicitly configured in this
+					// test is correct
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "start_webhook", testStartWebhook),
+					resource.TestCheckResourceAttr(resourceName, "stop_webhook", testStopWebhook),
+					resource.TestCheckResourceAttr(resourceName, "health_webhook", testHealthWebhook),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDacTarget_Disappears(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_dac_target.test"
+	var target targets.DynamicAccessConfiguration
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDacTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDacTargetConfigBasic(rName, env.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					acctest.CheckResourceDisappears(resourceName, func(c *bastionzero.Client, ctx context.Context, id string) (*http.Response, error) {
+						return c.Targets.DeleteDynamicAccessConfiguration(ctx, id)
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDacTarget_EnvironmentID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_dac_target.test"
+	var target targets.DynamicAccessConfiguration
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env1 := new(environments.Environment)
+	env2 := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env1, env2)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDacTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDacTargetConfigBasic(rName, env1.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						EnvironmentID: &env1.ID,
+						Name:          &rName,
+					}),
+					testAccCheckResourceDacTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env1.ID),
+				),
+			},
+			// Verify update environment
+			{
+				Config: testAccDacTargetConfigBasic(rName, env2.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						EnvironmentID: &env2.ID,
+						Name:          &rName,
+					}),
+					testAccCheckResourceDacTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env2.ID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDacTarget_Name(t *testing.T) {
+	ctx := context.Background()
+	rName1 := acctest.RandomName()
+	rName2 := acctest.RandomName()
+	resourceName := "bastionzero_dac_target.test"
+	var target targets.DynamicAccessConfiguration
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDacTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDacTargetConfigBasic(rName1, env.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName1,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", rName1),
+				),
+			},
+			// Verify update name
+			{
+				Config: testAccDacTargetConfigBasic(rName2, env.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName2,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", rName2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDacTarget_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_dac_target.test"
+	var target targets.DynamicAccessConfiguration
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+
+	newStartWebhook := "https://example.org/v2/start"
+	newStopWebhook := "https://example.org/v2/stop"
+	newHealthWebhook := "https://example.org/v2/health"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDacTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDacTargetConfigBasic(rName, env.ID, testStartWebhook, testStopWebhook, testHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						StartWebhook:  bastionzero.PtrTo(testStartWebhook),
+						StopWebhook:   bastionzero.PtrTo(testStopWebhook),
+						HealthWebhook: bastionzero.PtrTo(testHealthWebhook),
+					}),
+				),
+			},
+			// Verify update webhooks
+			{
+				Config: testAccDacTargetConfigBasic(rName, env.ID, newStartWebhook, newStopWebhook, newHealthWebhook),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDacTargetExists(resourceName, &target),
+					testAccCheckDacTargetAttributes(&target, &expectedDacTarget{
+						StartWebhook:  &newStartWebhook,
+						StopWebhook:   &newStopWebhook,
+						HealthWebhook: &newHealthWebhook,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "start_webhook", newStartWebhook),
+					resource.TestCheckResourceAttr(resourceName, "stop_webhook", newStopWebhook),
+					resource.TestCheckResourceAttr(resourceName, "health_webhook", newHealthWebhook),
+				),
+			},
+		},
+	})
+}
+
+func TestDacTarget_InvalidName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Empty name not permitted
+				Config:      testAccDacTargetConfigBasic("", uuid.New().String(), testStartWebhook, testStopWebhook, testHealthWebhook),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func TestDacTarget_InvalidEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccDacTargetConfigBasic("foo", "bad-id", testStartWebhook, testStopWebhook, testHealthWebhook),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestDacTarget_InvalidWebhooks(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Relative URL not permitted
+				Config:      testAccDacTargetConfigBasic("foo", uuid.New().String(), "/start", testStopWebhook, testHealthWebhook),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			{
+				// Non-HTTP scheme not permitted
+				Config:      testAccDacTargetConfigBasic("foo", uuid.New().String(), testStartWebhook, "ftp://example.com/stop", testHealthWebhook),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			{
+				// Empty URL not permitted
+				Config:      testAccDacTargetConfigBasic("foo", uuid.New().String(), testStartWebhook, testStopWebhook, ""),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func testAccDacTargetConfigBasic(name string, envID string, startWebhook string, stopWebhook string, healthWebhook string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_dac_target" "test" {
+  environment_id = %[2]q
+  name = %[1]q
+  start_webhook = %[3]q
+  stop_webhook = %[4]q
+  health_webhook = %[5]q
+}
+`, name, envID, startWebhook, stopWebhook, healthWebhook)
+}
+
+type expectedDacTarget struct {
+	EnvironmentID *string
+	Name          *string
+	StartWebhook  *string
+	StopWebhook   *string
+	HealthWebhook *string
+}
+
+func testAccCheckDacTargetAttributes(target *targets.DynamicAccessConfiguration, expected *expectedDacTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if expected.EnvironmentID != nil && *expected.EnvironmentID != target.EnvironmentId {
+			return fmt.Errorf("Bad environment_id, expected \"%s\", got: %#v", *expected.EnvironmentID, target.EnvironmentId)
+		}
+		if expected.Name != nil && *expected.Name != target.Name {
+			return fmt.Errorf("Bad name, expected \"%s\", got: %#v", *expected.Name, target.Name)
+		}
+		if expected.StartWebhook != nil && *expected.StartWebhook != target.StartWebhook {
+			return fmt.Errorf("Bad start_webhook, expected \"%s\", got: %#v", *expected.StartWebhook, target.StartWebhook)
+		}
+		if expected.StopWebhook != nil && *expected.StopWebhook != target.StopWebhook {
+			return fmt.Errorf("Bad stop_webhook, expected \"%s\", got: %#v", *expected.StopWebhook, target.StopWebhook)
+		}
+		if expected.HealthWebhook != nil && *expected.HealthWebhook != target.HealthWebhook {
+			return fmt.Errorf("Bad health_webhook, expected \"%s\", got: %#v", *expected.HealthWebhook, target.HealthWebhook)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDacTargetExists(namedTFResource string, target *targets.DynamicAccessConfiguration) resource.TestCheckFunc {
+	return acctest.CheckExistsAtBastionZero(namedTFResource, target, func(c *bastionzero.Client, ctx context.Context, id string) (*targets.DynamicAccessConfiguration, *http.Response, error) {
+		return c.Targets.GetDynamicAccessConfiguration(ctx, id)
+	})
+}
+
+func testAccCheckResourceDacTargetComputedAttr(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(acctest.UUIDV4RegexPattern)),
+		acctest.CheckResourceAttrIsOneOf(resourceName, "status", bastionzero.ToStringSlice(dacstatus.DynamicAccessConfigurationStatusValues())),
+		resource.TestCheckResourceAttr(resourceName, "type", string(targettype.DynamicAccessConfig)),
+	)
+}
+
+func testAccCheckDacTargetDestroy(s *terraform.State) error {
+	return acctest.CheckAllResourcesWithTypeDestroyed(
+		"bastionzero_dac_target",
+		func(client *bastionzero.Client, ctx context.Context, id string) (*targets.DynamicAccessConfiguration, *http.Response, error) {
+			return client.Targets.GetDynamicAccessConfiguration(ctx, id)
+		},
+	)(s)
+}
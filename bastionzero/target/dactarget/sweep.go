@@ -0,0 +1,42 @@
+package dactarget
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("bastionzero_dac_target", &resource.Sweeper{
+		Name: "bastionzero_dac_target",
+		F:    sweepDacTarget,
+	})
+
+}
+
+func sweepDacTarget(region string) error {
+	client, err := sweep.SweeperClient()
+	if err != nil {
+		return err
+	}
+
+	dacTargets, _, err := client.Targets.ListDynamicAccessConfigurations(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, dacTarget := range dacTargets {
+		if strings.HasPrefix(dacTarget.Name, sweep.TestNamePrefix) {
+			log.Printf("Destroying DAC target %s (%s)", dacTarget.Name, dacTarget.ID)
+
+			if _, err := client.Targets.DeleteDynamicAccessConfiguration(context.Background(), dacTarget.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -30,8 +31,10 @@ func NewDbTargetsDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all Db targets in your BastionZero organization.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]targets.DatabaseTarget, error) {
-			targets, _, err := client.Targets.ListDatabaseTargets(ctx)
-			return targets, err
+			return listcache.List(ctx, listcache.KindDbTarget, "", func(ctx context.Context) ([]targets.DatabaseTarget, error) {
+				targets, _, err := client.Targets.ListDatabaseTargets(ctx)
+				return targets, err
+			})
 		},
 	})
 }
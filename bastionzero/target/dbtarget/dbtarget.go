@@ -9,20 +9,15 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/dbauthconfig"
-	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal"
-	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -142,6 +137,14 @@ func (t *dbTargetResourceModel) SetRemoteHost(value types.String) { t.RemoteHost
 func (t *dbTargetResourceModel) SetRemotePort(value types.Int64)  { t.RemotePort = value }
 func (t *dbTargetResourceModel) SetLocalPort(value types.Int64)   { t.LocalPort = value }
 
+func (t *dbTargetResourceModel) GetName() types.String               { return t.Name }
+func (t *dbTargetResourceModel) GetEnvironmentID() types.String      { return t.EnvironmentID }
+func (t *dbTargetResourceModel) GetProxyTargetID() types.String      { return t.ProxyTargetID }
+func (t *dbTargetResourceModel) GetProxyEnvironmentID() types.String { return t.ProxyEnvironmentID }
+func (t *dbTargetResourceModel) GetRemoteHost() types.String         { return t.RemoteHost }
+func (t *dbTargetResourceModel) GetRemotePort() types.Int64          { return t.RemotePort }
+func (t *dbTargetResourceModel) GetLocalPort() types.Int64           { return t.LocalPort }
+
 // setDbTargetResourceAttributes populates the TF schema data from a db target
 // API object.
 func setDbTargetResourceAttributes(ctx context.Context, schema *dbTargetResourceModel, dbTarget *targets.DatabaseTarget) {
@@ -171,137 +174,60 @@ func makeDbTargetResourceSchema(ctx context.Context) map[string]resource_schema.
 		dbauthconfig.Postgres,
 	}
 
-	return map[string]resource_schema.Attribute{
-		"id": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: "The target's unique ID.",
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.UseStateForUnknown(),
-			},
-		},
-		"name": resource_schema.StringAttribute{
-			Required:    true,
-			Description: "The target's name.",
-			Validators: []validator.String{
-				stringvalidator.LengthAtLeast(1),
-			},
-		},
-		"type": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: fmt.Sprintf("The target's type (constant value `%s`).", targettype.Db),
-			Default:     stringdefault.StaticString(string(targettype.Db)),
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.UseStateForUnknown(),
-			},
-		},
-		"status": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: fmt.Sprintf("The target's status %s.", internal.PrettyOneOf(targetstatus.TargetStatusValues())),
-			Validators: []validator.String{
-				stringvalidator.OneOf(bastionzero.ToStringSlice(targetstatus.TargetStatusValues())...),
-			},
-		},
-		"environment_id": resource_schema.StringAttribute{
-			Required:    true,
-			Description: "The target's environment's ID.",
-			Validators: []validator.String{
-				bzvalidator.ValidUUIDV4(),
-			},
-		},
-		"last_agent_update": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: fmt.Sprintf("The time this target's proxy agent last had a transition change in status %s. Null if there has not been a single transition change.", internal.PrettyRFC3339Timestamp()),
-		},
-		"agent_version": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: "The target's proxy agent's version.",
-		},
-		"region": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: "The BastionZero region that this target has connected to (follows same naming convention as AWS regions).",
-		},
-		"agent_public_key": resource_schema.StringAttribute{
-			Computed:    true,
-			Description: "The target's proxy agent's public key.",
-		},
-		"proxy_target_id": resource_schema.StringAttribute{
-			Optional:            true,
-			Description:         "The target's proxy target's ID (ID of a Linux, Windows, or Kubernetes target).",
-			MarkdownDescription: "The target's proxy target's ID (ID of a [Linux](../data-sources/bzero_target), [Windows](../data-sources/bzero_target), or [Kubernetes](../data-sources/cluster_target) target).",
-			Validators: []validator.String{
-				bzvalidator.ValidUUIDV4(),
-			},
-		},
-		"proxy_environment_id": resource_schema.StringAttribute{
-			Optional:    true,
-			Description: "The target's proxy environment's ID (ID of the backing proxy environment).",
-			Validators: []validator.String{
-				bzvalidator.ValidUUIDV4(),
-			},
-		},
-		"remote_host": resource_schema.StringAttribute{
-			Required:    true,
-			Description: "The target's hostname or IP address.",
-			Validators: []validator.String{
-				stringvalidator.LengthAtLeast(1),
-			},
-		},
-		"remote_port": resource_schema.Int64Attribute{
-			Required:    true,
-			Description: fmt.Sprintf("The port of the %v server accessible via the target. This field is required for all databases; however, if `database_authentication_config.cloud_service_provider` is equal to `%v`, then the value will be ignored when connecting to the database (we recommend using value `0` in this case).", targettype.Db, dbauthconfig.GCP),
-		},
-		"local_port": resource_schema.Int64Attribute{
-			Optional:    true,
-			Description: fmt.Sprintf("The port of the %v daemon's localhost server that is spawned on the user's machine on connect. If this attribute is left unconfigured, an available port will be chosen when the target is connected to.", targettype.Db),
-		},
-		"database_authentication_config": resource_schema.SingleNestedAttribute{
-			Optional: true,
-			Computed: true,
-			// Important to set this default, even though backend sets it for us
-			// when unspecified, because it makes it easier to keep the TF state
-			// and TF config in sync with the backend. Removing this code will
-			// make it so terraform thinks any TF config that does not set
-			// db_auth_config require an update to the remote state when it
-			// really does not
-			Default: objectdefault.StaticValue(FlattenDatabaseAuthenticationConfig(ctx,
-				&dbauthconfig.DatabaseAuthenticationConfig{
-					AuthenticationType: bastionzero.PtrTo(dbauthconfig.Default),
-					Label:              bastionzero.PtrTo("None"),
-				}),
-			),
-			Description: "Information about the db target's database authentication configuration. If this attribute is left unconfigured, the target is configured with the default, non-passwordless database configuration.",
-			Attributes: map[string]resource_schema.Attribute{
-				"authentication_type": resource_schema.StringAttribute{
-					Required:    true,
-					Description: fmt.Sprintf("The type of authentication used when connecting to the database %s.", internal.PrettyOneOf(validAuthenticationTypes)),
-					Validators: []validator.String{
-						stringvalidator.OneOf(validAuthenticationTypes...),
-					},
+	dbTargetAttributes := target.VirtualTargetResourceAttributes(targettype.Db)
+	dbTargetAttributes["remote_port"] = resource_schema.Int64Attribute{
+		Required:    true,
+		Description: fmt.Sprintf("The port of the %v server accessible via the target. This field is required for all databases; however, if `database_authentication_config.cloud_service_provider` is equal to `%v`, then the value will be ignored when connecting to the database (we recommend using value `0` in this case).", targettype.Db, dbauthconfig.GCP),
+	}
+	dbTargetAttributes["database_authentication_config"] = resource_schema.SingleNestedAttribute{
+		Optional: true,
+		Computed: true,
+		// Important to set this default, even though backend sets it for us
+		// when unspecified, because it makes it easier to keep the TF state
+		// and TF config in sync with the backend. Removing this code will
+		// make it so terraform thinks any TF config that does not set
+		// db_auth_config require an update to the remote state when it
+		// really does not
+		Default: objectdefault.StaticValue(FlattenDatabaseAuthenticationConfig(ctx,
+			&dbauthconfig.DatabaseAuthenticationConfig{
+				AuthenticationType: bastionzero.PtrTo(dbauthconfig.Default),
+				Label:              bastionzero.PtrTo("None"),
+			}),
+		),
+		Description: "Information about the db target's database authentication configuration. If this attribute is left unconfigured, the target is configured with the default, non-passwordless database configuration.",
+		Attributes: map[string]resource_schema.Attribute{
+			"authentication_type": resource_schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The type of authentication used when connecting to the database %s.", internal.PrettyOneOf(validAuthenticationTypes)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(validAuthenticationTypes...),
 				},
-				"cloud_service_provider": resource_schema.StringAttribute{
-					Optional:    true,
-					Description: fmt.Sprintf("Cloud service provider hosting the database %s. Only used for certain types of authentication (`authentication_type`), such as `ServiceAccountInjection`.", internal.PrettyOneOf(validCloudServiceProviders)),
-					Validators: []validator.String{
-						stringvalidator.OneOf(validCloudServiceProviders...),
-					},
+			},
+			"cloud_service_provider": resource_schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Cloud service provider hosting the database %s. Only used for certain types of authentication (`authentication_type`), such as `ServiceAccountInjection`.", internal.PrettyOneOf(validCloudServiceProviders)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(validCloudServiceProviders...),
 				},
-				"database": resource_schema.StringAttribute{
-					Optional:    true,
-					Description: fmt.Sprintf("The type of database running on the target %s.", internal.PrettyOneOf(validDatabases)),
-					Validators: []validator.String{
-						stringvalidator.OneOf(validDatabases...),
-					},
+			},
+			"database": resource_schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The type of database running on the target %s.", internal.PrettyOneOf(validDatabases)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(validDatabases...),
 				},
-				"label": resource_schema.StringAttribute{
-					Optional:    true,
-					Description: "User-friendly label for this database authentication configuration.",
-					Validators: []validator.String{
-						stringvalidator.LengthAtLeast(1),
-					},
+			},
+			"label": resource_schema.StringAttribute{
+				Optional:    true,
+				Description: "User-friendly label for this database authentication configuration.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 		},
 	}
+
+	return dbTargetAttributes
 }
 
 type DatabaseAuthenticationConfigModel struct {
@@ -10,7 +10,12 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/dbauthconfig"
-	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -33,7 +38,10 @@ func NewDbTargetResource() resource.Resource {
 
 // dbTargetResource is the resource implementation.
 type dbTargetResource struct {
-	client *bastionzero.Client
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
 }
 
 // Configure adds the provider configured BastionZero API client to the
@@ -44,17 +52,20 @@ func (r *dbTargetResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*bastionzero.Client)
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource configure type",
-			fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
 }
 
 // Metadata returns the db target resource type name.
@@ -72,6 +83,10 @@ func (r *dbTargetResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 
 // Create creates the db target resource and sets the initial Terraform state.
 func (r *dbTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_db_target", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDbTarget, listcache.KindEnvironment)
+
 	// Read Terraform plan data into the model
 	var plan dbTargetResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -123,10 +138,14 @@ func (r *dbTargetResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_db_target", plan.ID.ValueString(), resp.State)...)
 }
 
 // Read refreshes the db target Terraform state with the latest data.
 func (r *dbTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_db_target", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
 	// Read Terraform prior state data into the model
 	var state dbTargetResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -154,6 +173,10 @@ func (r *dbTargetResource) Read(ctx context.Context, req resource.ReadRequest, r
 // Update updates the db target resource and sets the updated Terraform state on
 // success.
 func (r *dbTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_db_target", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDbTarget, listcache.KindEnvironment)
+
 	// Read Terraform plan and current state data into the model
 	var plan, state dbTargetResourceModel
 
@@ -166,32 +189,15 @@ func (r *dbTargetResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Generate API request body from plan. Only include things in request that
 	// have changed between plan and current state
-	modifyReq := new(targets.ModifyDatabaseTargetRequest)
-	if !plan.Name.Equal(state.Name) {
-		modifyReq.TargetName = bastionzero.PtrTo(plan.Name.ValueString())
-	}
-	if !plan.ProxyTargetID.Equal(state.ProxyTargetID) {
-		modifyReq.ProxyTargetID = plan.ProxyTargetID.ValueStringPointer()
-	}
-	if !plan.ProxyEnvironmentID.Equal(state.ProxyEnvironmentID) {
-		modifyReq.ProxyEnvironmentID = plan.ProxyEnvironmentID.ValueStringPointer()
-	}
-	if !plan.RemoteHost.Equal(state.RemoteHost) {
-		modifyReq.RemoteHost = bastionzero.PtrTo(plan.RemoteHost.ValueString())
-	}
-	if !plan.RemotePort.Equal(state.RemotePort) {
-		modifyReq.RemotePort = &targets.Port{Value: bastionzero.PtrTo(int(plan.RemotePort.ValueInt64()))}
-	}
-	if !plan.LocalPort.Equal(state.LocalPort) {
-		if !plan.LocalPort.IsNull() {
-			modifyReq.LocalPort = &targets.Port{Value: bastionzero.PtrTo(int(plan.LocalPort.ValueInt64()))}
-		} else {
-			// Send an empty Port value to reset this value to blank
-			modifyReq.LocalPort = &targets.Port{Value: nil}
-		}
-	}
-	if !plan.EnvironmentID.Equal(state.EnvironmentID) {
-		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.EnvironmentID.ValueString())
+	changes := target.ExpandVirtualTargetModifyRequest(&plan, &state)
+	modifyReq := &targets.ModifyDatabaseTargetRequest{
+		TargetName:         changes.TargetName,
+		ProxyTargetID:      changes.ProxyTargetID,
+		ProxyEnvironmentID: changes.ProxyEnvironmentID,
+		RemoteHost:         changes.RemoteHost,
+		RemotePort:         changes.RemotePort,
+		LocalPort:          changes.LocalPort,
+		EnvironmentID:      changes.EnvironmentID,
 	}
 	if !plan.DatabaseAuthenticationConfig.Equal(state.DatabaseAuthenticationConfig) {
 		modifyReq.DatabaseAuthenticationConfig = ExpandDatabaseAuthenticationConfig(ctx, plan.DatabaseAuthenticationConfig)
@@ -211,11 +217,16 @@ func (r *dbTargetResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Overwrite with refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_db_target", plan.ID.ValueString(), req.State, resp.State)...)
 }
 
 // Delete deletes the db target resource and removes the Terraform state on
 // success.
 func (r *dbTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_db_target", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindDbTarget, listcache.KindEnvironment)
+
 	// Retrieve values from state
 	var state dbTargetResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -239,6 +250,7 @@ func (r *dbTargetResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 	tflog.Debug(ctx, "Deleted db target")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_db_target", state.ID.ValueString(), req.State)...)
 }
 
 func (r *dbTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -247,6 +259,13 @@ func (r *dbTargetResource) ImportState(ctx context.Context, req resource.ImportS
 }
 
 func (r *dbTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("db target", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Check if the resource is being destroyed
 	if req.Plan.Raw.IsNull() {
 		// Return early as the following checks don't matter during destruction.
@@ -325,18 +344,5 @@ func (r *dbTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 }
 
 func (r *dbTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{
-		// Validate that db_target is not configured with both proxy_target_id
-		// and proxy_environment_id (known, non-null values).
-		resourcevalidator.Conflicting(
-			path.MatchRoot("proxy_target_id"),
-			path.MatchRoot("proxy_environment_id"),
-		),
-		// Validate at least one of the schema defined attributes named
-		// proxy_target_id and proxy_environment_id has a known, non-null value.
-		resourcevalidator.AtLeastOneOf(
-			path.MatchRoot("proxy_target_id"),
-			path.MatchRoot("proxy_environment_id"),
-		),
-	}
+	return target.VirtualTargetConfigValidators()
 }
@@ -0,0 +1,198 @@
+package target
+
+import (
+	"fmt"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// VirtualTargetResourceAttributes returns a map of common TF attributes used by
+// the database and web resource schemas.
+func VirtualTargetResourceAttributes(targetType targettype.TargetType) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The target's unique ID.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The target's name.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"type": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The target's type (constant value `%s`).", targetType),
+			Default:     stringdefault.StaticString(string(targetType)),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The target's status %s.", internal.PrettyOneOf(targetstatus.TargetStatusValues())),
+			Validators: []validator.String{
+				stringvalidator.OneOf(bastionzero.ToStringSlice(targetstatus.TargetStatusValues())...),
+			},
+		},
+		"environment_id": schema.StringAttribute{
+			Required:    true,
+			Description: "The target's environment's ID.",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+		},
+		"last_agent_update": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The time this target's proxy agent last had a transition change in status %s. Null if there has not been a single transition change.", internal.PrettyRFC3339Timestamp()),
+		},
+		"agent_version": schema.StringAttribute{
+			Computed:    true,
+			Description: "The target's proxy agent's version.",
+		},
+		"region": schema.StringAttribute{
+			Computed:    true,
+			Description: "The BastionZero region that this target has connected to (follows same naming convention as AWS regions).",
+		},
+		"agent_public_key": schema.StringAttribute{
+			Computed:    true,
+			Description: "The target's proxy agent's public key.",
+		},
+		"proxy_target_id": schema.StringAttribute{
+			Optional:            true,
+			Description:         "The target's proxy target's ID (ID of a Linux, Windows, or Kubernetes target).",
+			MarkdownDescription: "The target's proxy target's ID (ID of a [Linux](../data-sources/bzero_target), [Windows](../data-sources/bzero_target), or [Kubernetes](../data-sources/cluster_target) target).",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+		},
+		"proxy_environment_id": schema.StringAttribute{
+			Optional:    true,
+			Description: "The target's proxy environment's ID (ID of the backing proxy environment).",
+			Validators: []validator.String{
+				bzvalidator.ValidUUIDV4(),
+			},
+		},
+		"remote_host": schema.StringAttribute{
+			Required:    true,
+			Description: "The target's hostname or IP address.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"remote_port": schema.Int64Attribute{
+			Required:    true,
+			Description: fmt.Sprintf("The port of the %v server accessible via the target.", targetType),
+		},
+		"local_port": schema.Int64Attribute{
+			Optional:    true,
+			Description: fmt.Sprintf("The port of the %v daemon's localhost server that is spawned on the user's machine on connect. If this attribute is left unconfigured, an available port will be chosen when the target is connected to.", targetType),
+		},
+	}
+}
+
+// VirtualTargetConfigValidators returns the config validators used by the
+// database and web resources. A virtual target is proxied by either a target
+// or an environment, but not both.
+func VirtualTargetConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Validate that the target is not configured with both proxy_target_id
+		// and proxy_environment_id (known, non-null values).
+		resourcevalidator.Conflicting(
+			path.MatchRoot("proxy_target_id"),
+			path.MatchRoot("proxy_environment_id"),
+		),
+		// Validate at least one of the schema defined attributes named
+		// proxy_target_id and proxy_environment_id has a known, non-null value.
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("proxy_target_id"),
+			path.MatchRoot("proxy_environment_id"),
+		),
+	}
+}
+
+// VirtualTargetResourceModelInterface lets you read the common attributes
+// managed by the database and web resources.
+type VirtualTargetResourceModelInterface interface {
+	// GetName gets the target model's name attribute.
+	GetName() types.String
+	// GetEnvironmentID gets the target model's environment_id attribute.
+	GetEnvironmentID() types.String
+	// GetProxyTargetID gets the target model's proxy_target_id attribute.
+	GetProxyTargetID() types.String
+	// GetProxyEnvironmentID gets the target model's proxy_environment_id
+	// attribute.
+	GetProxyEnvironmentID() types.String
+	// GetRemoteHost gets the target model's remote_host attribute.
+	GetRemoteHost() types.String
+	// GetRemotePort gets the target model's remote_port attribute.
+	GetRemotePort() types.Int64
+	// GetLocalPort gets the target model's local_port attribute.
+	GetLocalPort() types.Int64
+}
+
+// VirtualTargetModifyRequest holds the fields common to the database and web
+// target modify requests. A nil field is left unchanged.
+type VirtualTargetModifyRequest struct {
+	TargetName         *string
+	ProxyTargetID      *string
+	ProxyEnvironmentID *string
+	RemoteHost         *string
+	RemotePort         *targets.Port
+	LocalPort          *targets.Port
+	EnvironmentID      *string
+}
+
+// ExpandVirtualTargetModifyRequest returns the common modify request fields of
+// a virtual target. Only the attributes that have changed between plan and
+// state are set.
+func ExpandVirtualTargetModifyRequest(plan, state VirtualTargetResourceModelInterface) *VirtualTargetModifyRequest {
+	modifyReq := new(VirtualTargetModifyRequest)
+	if !plan.GetName().Equal(state.GetName()) {
+		modifyReq.TargetName = bastionzero.PtrTo(plan.GetName().ValueString())
+	}
+	if !plan.GetProxyTargetID().Equal(state.GetProxyTargetID()) {
+		modifyReq.ProxyTargetID = plan.GetProxyTargetID().ValueStringPointer()
+	}
+	if !plan.GetProxyEnvironmentID().Equal(state.GetProxyEnvironmentID()) {
+		modifyReq.ProxyEnvironmentID = plan.GetProxyEnvironmentID().ValueStringPointer()
+	}
+	if !plan.GetRemoteHost().Equal(state.GetRemoteHost()) {
+		modifyReq.RemoteHost = bastionzero.PtrTo(plan.GetRemoteHost().ValueString())
+	}
+	if !plan.GetRemotePort().Equal(state.GetRemotePort()) {
+		modifyReq.RemotePort = &targets.Port{Value: bastionzero.PtrTo(int(plan.GetRemotePort().ValueInt64()))}
+	}
+	if !plan.GetLocalPort().Equal(state.GetLocalPort()) {
+		if !plan.GetLocalPort().IsNull() {
+			modifyReq.LocalPort = &targets.Port{Value: bastionzero.PtrTo(int(plan.GetLocalPort().ValueInt64()))}
+		} else {
+			// Send an empty Port value to reset this value to blank
+			modifyReq.LocalPort = &targets.Port{Value: nil}
+		}
+	}
+	if !plan.GetEnvironmentID().Equal(state.GetEnvironmentID()) {
+		modifyReq.EnvironmentID = bastionzero.PtrTo(plan.GetEnvironmentID().ValueString())
+	}
+
+	return modifyReq
+}
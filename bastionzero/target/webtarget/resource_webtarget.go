@@ -0,0 +1,263 @@
+package webtarget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &webTargetResource{}
+	_ resource.ResourceWithConfigure        = &webTargetResource{}
+	_ resource.ResourceWithImportState      = &webTargetResource{}
+	_ resource.ResourceWithModifyPlan       = &webTargetResource{}
+	_ resource.ResourceWithConfigValidators = &webTargetResource{}
+)
+
+func NewWebTargetResource() resource.Resource {
+	return &webTargetResource{}
+}
+
+// webTargetResource is the resource implementation.
+type webTargetResource struct {
+	client     *bastionzero.Client
+	guardrails *guardrail.Guardrails
+	auditLog   *auditlog.Logger
+	listCache  *listcache.Cache
+}
+
+// Configure adds the provider configured BastionZero API client to the
+// resource.
+func (r *webTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource configure type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.guardrails = providerData.Guardrails
+	r.auditLog = providerData.AuditLog
+	r.listCache = providerData.ListCache
+}
+
+// Metadata returns the web target resource type name.
+func (r *webTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_web_target"
+}
+
+// Schema defines the schema for the web target resource.
+func (r *webTargetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provides a BastionZero web target. Web targets configure remote access to web servers running on [Linux](../data-sources/bzero_target), [Windows](../data-sources/bzero_target), or [Kubernetes](../data-sources/cluster_target) targets.",
+		Attributes:          makeWebTargetResourceSchema(),
+	}
+}
+
+// Create creates the web target resource and sets the initial Terraform state.
+func (r *webTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_web_target", telemetry.OperationCreate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindWebTarget, listcache.KindEnvironment)
+
+	// Read Terraform plan data into the model
+	var plan webTargetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	createReq := new(targets.CreateWebTargetRequest)
+	createReq.TargetName = plan.Name.ValueString()
+	if !plan.ProxyTargetID.IsNull() {
+		createReq.ProxyTargetID = plan.ProxyTargetID.ValueString()
+	}
+	if !plan.ProxyEnvironmentID.IsNull() {
+		createReq.ProxyEnvironmentID = plan.ProxyEnvironmentID.ValueString()
+	}
+	createReq.RemoteHost = plan.RemoteHost.ValueString()
+	createReq.RemotePort = targets.Port{Value: bastionzero.PtrTo(int(plan.RemotePort.ValueInt64()))}
+	if !plan.LocalPort.IsNull() {
+		createReq.LocalPort = &targets.Port{Value: bastionzero.PtrTo(int(plan.LocalPort.ValueInt64()))}
+	}
+	createReq.EnvironmentID = plan.EnvironmentID.ValueString()
+
+	// Create new web target
+	tflog.Debug(ctx, "Creating web target")
+	createResp, _, err := r.client.Targets.CreateWebTarget(ctx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating web target",
+			"Could not create web target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	ctx = tflog.SetField(ctx, "web_target_id", createResp.TargetId)
+	tflog.Debug(ctx, "Created web target")
+	plan.ID = types.StringValue(createResp.TargetId)
+
+	// Query using the GET API to populate other attributes
+	found, diags := readWebTarget(ctx, &plan, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Failed to find web target after create", "")
+		return
+	}
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordCreate(ctx, "bastionzero_web_target", plan.ID.ValueString(), resp.State)...)
+}
+
+// Read refreshes the web target Terraform state with the latest data.
+func (r *webTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_web_target", telemetry.OperationRead)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	// Read Terraform prior state data into the model
+	var state webTargetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "web_target_id", state.ID.ValueString())
+
+	// Read web target
+	found, diags := readWebTarget(ctx, &state, r.client)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		// The next terraform plan will recreate the resource
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the web target resource and sets the updated Terraform state on
+// success.
+func (r *webTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_web_target", telemetry.OperationUpdate)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindWebTarget, listcache.KindEnvironment)
+
+	// Read Terraform plan and current state data into the model
+	var plan, state webTargetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "web_target_id", plan.ID.ValueString())
+
+	// Generate API request body from plan. Only include things in request that
+	// have changed between plan and current state
+	changes := target.ExpandVirtualTargetModifyRequest(&plan, &state)
+	modifyReq := &targets.ModifyWebTargetRequest{
+		TargetName:         changes.TargetName,
+		ProxyTargetID:      changes.ProxyTargetID,
+		ProxyEnvironmentID: changes.ProxyEnvironmentID,
+		RemoteHost:         changes.RemoteHost,
+		RemotePort:         changes.RemotePort,
+		LocalPort:          changes.LocalPort,
+		EnvironmentID:      changes.EnvironmentID,
+	}
+
+	// Update existing web target
+	updateResp, _, err := r.client.Targets.ModifyWebTarget(ctx, plan.ID.ValueString(), modifyReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating web target",
+			"Could not update web target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	setWebTargetAttributes(ctx, &plan, updateResp)
+
+	// Overwrite with refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.auditLog.RecordUpdate(ctx, "bastionzero_web_target", plan.ID.ValueString(), req.State, resp.State)...)
+}
+
+// Delete deletes the web target resource and removes the Terraform state on
+// success.
+func (r *webTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := telemetry.StartResourceSpan(ctx, "bastionzero_web_target", telemetry.OperationDelete)
+	defer telemetry.EndSpan(ctx, span, &req.State, &resp.Diagnostics)
+	defer r.listCache.Invalidate(listcache.KindWebTarget, listcache.KindEnvironment)
+
+	// Retrieve values from state
+	var state webTargetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = tflog.SetField(ctx, "web_target_id", state.ID.ValueString())
+
+	// Delete existing web target
+	tflog.Debug(ctx, "Deleting web target")
+	_, err := r.client.Targets.DeleteWebTarget(ctx, state.ID.ValueString())
+
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		// Return early without error if web target is already deleted
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting web target",
+			"Could not delete web target, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted web target")
+	resp.Diagnostics.Append(r.auditLog.RecordDelete(ctx, "bastionzero_web_target", state.ID.ValueString(), req.State)...)
+}
+
+func (r *webTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *webTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.guardrails.ValidatePlannedChange("web target", req, resp)
+	r.guardrails.ValidateName(ctx, req, resp)
+	r.guardrails.ValidateEnvironmentReferences(ctx, path.Root("environment_id"), req, resp)
+}
+
+func (r *webTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return target.VirtualTargetConfigValidators()
+}
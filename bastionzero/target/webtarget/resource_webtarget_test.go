@@ -0,0 +1,814 @@
+package webtarget_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/environments"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets/targetstatus"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/acctest"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccWebTarget_Basic(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			// Verify create works for a config set with all required attributes
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+						LocalPort:     nil,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					// Check the state value we explicitly configured in this
+					// test is correct
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env.ID),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_target_id", bzeroTarget.ID),
+					resource.TestCheckResourceAttr(resourceName, "remote_host", "http://localhost"),
+					resource.TestCheckResourceAttr(resourceName, "remote_port", "80"),
+					// Check that unspecified values remain null
+					resource.TestCheckNoResourceAttr(resourceName, "local_port"),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_Disappears(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					acctest.CheckResourceDisappears(resourceName, func(c *bastionzero.Client, ctx context.Context, id string) (*http.Response, error) {
+						return c.Targets.DeleteWebTarget(ctx, id)
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_EnvironmentID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env1 := new(environments.Environment)
+	env2 := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env1, env2)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName, env1.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env1.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env1.ID),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update environment
+			{
+				Config: testAccWebTargetConfigBasic(rName, env2.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env2.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "environment_id", env2.ID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_Name(t *testing.T) {
+	ctx := context.Background()
+	rName1 := acctest.RandomName()
+	rName2 := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName1, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName1,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName1),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update name
+			{
+				Config: testAccWebTargetConfigBasic(rName2, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName2,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", rName2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_ProxyTargetID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget1 := new(targets.BzeroTarget)
+	bzeroTarget2 := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget1, bzeroTarget2)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget1.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget1.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_target_id", bzeroTarget1.ID),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update proxy target ID
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget2.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget2.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_target_id", bzeroTarget2.ID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_ProxyEnvironmentID(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env1 := new(environments.Environment)
+	env2 := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env1, env2)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigProxyEnvID(rName, env1.ID, env1.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID:      &env1.ID,
+						Name:               &rName,
+						ProxyEnvironmentID: &env1.ID,
+						RemoteHost:         bastionzero.PtrTo("http://localhost"),
+						RemotePort:         bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttrProxyEnvID(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_environment_id", env1.ID),
+					resource.TestCheckNoResourceAttr(resourceName, "proxy_target_id"),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update proxy environment ID
+			{
+				Config: testAccWebTargetConfigProxyEnvID(rName, env1.ID, env2.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID:      &env1.ID,
+						Name:               &rName,
+						ProxyEnvironmentID: &env2.ID,
+						RemoteHost:         bastionzero.PtrTo("http://localhost"),
+						RemotePort:         bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttrProxyEnvID(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_environment_id", env2.ID),
+					resource.TestCheckNoResourceAttr(resourceName, "proxy_target_id"),
+				),
+			},
+			// Verify switch from proxy environment ID --> proxy target ID
+			{
+				Config: testAccWebTargetConfigBasic(rName, env1.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env1.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_target_id", bzeroTarget.ID),
+					resource.TestCheckNoResourceAttr(resourceName, "proxy_environment_id"),
+				),
+			},
+			// And then back proxy target ID --> proxy environment ID
+			{
+				Config: testAccWebTargetConfigProxyEnvID(rName, env1.ID, env2.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID:      &env1.ID,
+						Name:               &rName,
+						ProxyEnvironmentID: &env2.ID,
+						RemoteHost:         bastionzero.PtrTo("http://localhost"),
+						RemotePort:         bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttrProxyEnvID(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "proxy_environment_id", env2.ID),
+					resource.TestCheckNoResourceAttr(resourceName, "proxy_target_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_RemoteHost(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_host", "http://localhost"),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update remote host
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost2", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost2"),
+						RemotePort:    bastionzero.PtrTo(80),
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_host", "http://localhost2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_RemotePort(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	remotePort1 := 3000
+	remotePort2 := 4000
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", strconv.Itoa(remotePort1)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    &remotePort1,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_port", strconv.Itoa(remotePort1)),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update remote port
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", strconv.Itoa(remotePort2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    &remotePort2,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_port", strconv.Itoa(remotePort2)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebTarget_LocalPort(t *testing.T) {
+	ctx := context.Background()
+	rName := acctest.RandomName()
+	resourceName := "bastionzero_web_target.test"
+	var target targets.WebTarget
+
+	acctest.SkipIfNotInAcceptanceTestMode(t)
+	acctest.PreCheck(ctx, t)
+
+	env := new(environments.Environment)
+	bzeroTarget := new(targets.BzeroTarget)
+	acctest.FindNEnvironmentsOrSkip(t, env)
+	acctest.FindNBzeroTargetsOrSkip(t, bzeroTarget)
+
+	localPort1 := 3000
+	localPort2 := 4000
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWebTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebTargetConfigLocalPort(rName, env.ID, bzeroTarget.ID, "http://localhost", "80", strconv.Itoa(localPort1)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+						LocalPort:     &localPort1,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "local_port", strconv.Itoa(localPort1)),
+				),
+			},
+			// Verify import works
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Verify update local port
+			{
+				Config: testAccWebTargetConfigLocalPort(rName, env.ID, bzeroTarget.ID, "http://localhost", "80", strconv.Itoa(localPort2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+						LocalPort:     &localPort2,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "local_port", strconv.Itoa(localPort2)),
+				),
+			},
+			// Verify setting to null clears it
+			{
+				Config: testAccWebTargetConfigBasic(rName, env.ID, bzeroTarget.ID, "http://localhost", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebTargetExists(resourceName, &target),
+					testAccCheckWebTargetAttributes(t, &target, &expectedWebTarget{
+						EnvironmentID: &env.ID,
+						Name:          &rName,
+						ProxyTargetID: &bzeroTarget.ID,
+						RemoteHost:    bastionzero.PtrTo("http://localhost"),
+						RemotePort:    bastionzero.PtrTo(80),
+						LocalPort:     nil,
+					}),
+					testAccCheckResourceWebTargetComputedAttr(resourceName),
+					resource.TestCheckNoResourceAttr(resourceName, "local_port"),
+				),
+			},
+		},
+	})
+}
+
+func TestWebTarget_MutualExclProxyTargetEnv(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Cannot specify both proxy target id and proxy environment id
+				Config: `
+				resource "bastionzero_web_target" "test" {
+			      name = "foo"
+				  proxy_target_id = "b6d841ca-39ae-414f-ab5b-14be29e5573a"
+				  proxy_environment_id = "b6d841ca-39ae-414f-ab5b-14be29e5573a"
+				  environment_id = "b6d841ca-39ae-414f-ab5b-14be29e5573a"
+				  remote_host = "http://localhost"
+				  remote_port = 80
+				}
+				`,
+				ExpectError: regexp.MustCompile(`cannot be configured together`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_AtLeastOneProxyTargetEnv(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// At least one of proxy_target_id or proxy_environment_id must
+				// be specified
+				Config: `
+				resource "bastionzero_web_target" "test" {
+			      name = "foo"
+				  environment_id = "b6d841ca-39ae-414f-ab5b-14be29e5573a"
+				  remote_host = "http://localhost"
+				  remote_port = 80
+				}
+				`,
+				ExpectError: regexp.MustCompile(`At least one of these attributes must be configured`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_InvalidName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Empty name not permitted
+				Config:      testAccWebTargetConfigBasic("", uuid.New().String(), uuid.New().String(), "http://localhost", "80"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_InvalidEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccWebTargetConfigBasic("foo", "bad-id", uuid.New().String(), "http://localhost", "80"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_InvalidProxyTargetID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccWebTargetConfigBasic("foo", uuid.New().String(), "", "http://localhost", "80"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_InvalidProxyEnvironmentID(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Invalid ID not permitted
+				Config:      testAccWebTargetConfigProxyEnvID("foo", uuid.New().String(), "foobar-bad-env-id", "http://localhost", "80"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func TestWebTarget_InvalidRemoteHost(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Empty remote host not permitted
+				Config:      testAccWebTargetConfigBasic("foo", uuid.New().String(), uuid.New().String(), "", "80"),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Length`),
+			},
+		},
+	})
+}
+
+func testAccWebTargetConfigBasic(name string, envID string, proxyTargetID string, remoteHost string, remotePort string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_web_target" "test" {
+  environment_id = %[2]q
+  name = %[1]q
+  proxy_target_id = %[3]q
+  remote_host = %[4]q
+  remote_port = %[5]q
+}
+`, name, envID, proxyTargetID, remoteHost, remotePort)
+}
+
+func testAccWebTargetConfigLocalPort(name string, envID string, proxyTargetID string, remoteHost string, remotePort string, localPort string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_web_target" "test" {
+  environment_id = %[2]q
+  name = %[1]q
+  proxy_target_id = %[3]q
+  remote_host = %[4]q
+  remote_port = %[5]q
+  local_port = %[6]q
+}
+`, name, envID, proxyTargetID, remoteHost, remotePort, localPort)
+}
+
+func testAccWebTargetConfigProxyEnvID(name string, envID string, proxyEnvID string, remoteHost string, remotePort string) string {
+	return fmt.Sprintf(`
+resource "bastionzero_web_target" "test" {
+  environment_id = %[2]q
+  name = %[1]q
+  proxy_environment_id = %[3]q
+  remote_host = %[4]q
+  remote_port = %[5]q
+}
+`, name, envID, proxyEnvID, remoteHost, remotePort)
+}
+
+type expectedWebTarget struct {
+	EnvironmentID      *string
+	Name               *string
+	ProxyTargetID      *string
+	ProxyEnvironmentID *string
+	RemoteHost         *string
+	RemotePort         *int
+	LocalPort          *int
+}
+
+func testAccCheckWebTargetAttributes(t *testing.T, target *targets.WebTarget, expected *expectedWebTarget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if expected.EnvironmentID != nil && *expected.EnvironmentID != target.EnvironmentID {
+			return fmt.Errorf("Bad environment_id, expected \"%s\", got: %#v", *expected.EnvironmentID, target.EnvironmentID)
+		}
+		if expected.Name != nil && *expected.Name != target.Name {
+			return fmt.Errorf("Bad name, expected \"%s\", got: %#v", *expected.Name, target.Name)
+		}
+		if expected.ProxyTargetID != nil && *expected.ProxyTargetID != target.ProxyTargetID {
+			return fmt.Errorf("Bad proxy_target_id, expected \"%s\", got: %#v", *expected.ProxyTargetID, target.ProxyTargetID)
+		}
+		if expected.ProxyTargetID == nil && target.ProxyTargetID != "" {
+			return fmt.Errorf("Bad proxy_target_id, expected \"%s\", got: %#v", "", target.ProxyTargetID)
+		}
+		if expected.ProxyEnvironmentID != nil && *expected.ProxyEnvironmentID != target.ProxyEnvironmentID {
+			return fmt.Errorf("Bad proxy_environment_id, expected \"%s\", got: %#v", *expected.ProxyEnvironmentID, target.ProxyEnvironmentID)
+		}
+		if expected.ProxyEnvironmentID == nil && target.ProxyEnvironmentID != "" {
+			return fmt.Errorf("Bad proxy_environment_id, expected \"%s\", got: %#v", "", target.ProxyEnvironmentID)
+		}
+		if expected.RemoteHost != nil && *expected.RemoteHost != target.RemoteHost {
+			return fmt.Errorf("Bad remote_host, expected \"%s\", got: %#v", *expected.RemoteHost, target.RemoteHost)
+		}
+		if expected.RemotePort != nil && !assert.Equal(t, expected.RemotePort, target.RemotePort.Value) {
+			return fmt.Errorf("Bad remote_port, expected \"%s\", got: %s", acctest.SafePrettyInt(expected.RemotePort), acctest.SafePrettyInt(target.RemotePort.Value))
+		}
+		if !assert.Equal(t, expected.LocalPort, target.LocalPort.Value) {
+			return fmt.Errorf("Bad local_port, expected \"%s\", got: %s", acctest.SafePrettyInt(expected.LocalPort), acctest.SafePrettyInt(target.LocalPort.Value))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckWebTargetExists(namedTFResource string, target *targets.WebTarget) resource.TestCheckFunc {
+	return acctest.CheckExistsAtBastionZero(namedTFResource, target, func(c *bastionzero.Client, ctx context.Context, id string) (*targets.WebTarget, *http.Response, error) {
+		return c.Targets.GetWebTarget(ctx, id)
+	})
+}
+
+func testAccCheckResourceWebTargetComputedAttr(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttrSet(resourceName, "agent_public_key"),
+		resource.TestCheckResourceAttrSet(resourceName, "agent_version"),
+		resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(acctest.UUIDV4RegexPattern)),
+		resource.TestMatchResourceAttr(resourceName, "last_agent_update", regexp.MustCompile(acctest.RFC3339RegexPattern)),
+		resource.TestCheckResourceAttrSet(resourceName, "region"),
+		acctest.CheckResourceAttrIsOneOf(resourceName, "status", bastionzero.ToStringSlice(targetstatus.TargetStatusValues())),
+		resource.TestCheckResourceAttr(resourceName, "type", string(targettype.Web)),
+	)
+}
+
+func testAccCheckResourceWebTargetComputedAttrProxyEnvID(resourceName string) resource.TestCheckFunc {
+	// When using proxy_environment_id, these computed attributes have slightly
+	// different values
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(resourceName, "agent_public_key", "n/a"),
+		resource.TestCheckResourceAttr(resourceName, "agent_version", "n/a"),
+		resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(acctest.UUIDV4RegexPattern)),
+		resource.TestCheckNoResourceAttr(resourceName, "last_agent_update"),
+		resource.TestCheckResourceAttr(resourceName, "region", "n/a"),
+		acctest.CheckResourceAttrIsOneOf(resourceName, "status", bastionzero.ToStringSlice(targetstatus.TargetStatusValues())),
+		resource.TestCheckResourceAttr(resourceName, "type", string(targettype.Web)),
+	)
+}
+
+func testAccCheckWebTargetDestroy(s *terraform.State) error {
+	return acctest.CheckAllResourcesWithTypeDestroyed(
+		"bastionzero_web_target",
+		func(client *bastionzero.Client, ctx context.Context, id string) (*targets.WebTarget, *http.Response, error) {
+			return client.Targets.GetWebTarget(ctx, id)
+		},
+	)(s)
+}
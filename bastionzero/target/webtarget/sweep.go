@@ -0,0 +1,42 @@
+package webtarget
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func init() {
+	resource.AddTestSweepers("bastionzero_web_target", &resource.Sweeper{
+		Name: "bastionzero_web_target",
+		F:    sweepWebTarget,
+	})
+
+}
+
+func sweepWebTarget(region string) error {
+	client, err := sweep.SweeperClient()
+	if err != nil {
+		return err
+	}
+
+	webTargets, _, err := client.Targets.ListWebTargets(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, webTarget := range webTargets {
+		if strings.HasPrefix(webTarget.Name, sweep.TestNamePrefix) {
+			log.Printf("Destroying Web target %s (%s)", webTarget.Name, webTarget.ID)
+
+			if _, err := client.Targets.DeleteWebTarget(context.Background(), webTarget.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
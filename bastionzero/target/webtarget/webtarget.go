@@ -2,16 +2,25 @@ package webtarget
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/apierror"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/types/targettype"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero/target"
-	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/maps"
 )
 
-// webTargetModel maps the web target schema data.
+// webTargetModel maps the web target schema data. The web target data source
+// and resource share this model as their schemas have the same attributes.
 type webTargetModel struct {
 	ID              types.String `tfsdk:"id"`
 	Name            types.String `tfsdk:"name"`
@@ -46,6 +55,14 @@ func (t *webTargetModel) SetRemoteHost(value types.String)         { t.RemoteHos
 func (t *webTargetModel) SetRemotePort(value types.Int64)          { t.RemotePort = value }
 func (t *webTargetModel) SetLocalPort(value types.Int64)           { t.LocalPort = value }
 
+func (t *webTargetModel) GetName() types.String               { return t.Name }
+func (t *webTargetModel) GetEnvironmentID() types.String      { return t.EnvironmentID }
+func (t *webTargetModel) GetProxyTargetID() types.String      { return t.ProxyTargetID }
+func (t *webTargetModel) GetProxyEnvironmentID() types.String { return t.ProxyEnvironmentID }
+func (t *webTargetModel) GetRemoteHost() types.String         { return t.RemoteHost }
+func (t *webTargetModel) GetRemotePort() types.Int64          { return t.RemotePort }
+func (t *webTargetModel) GetLocalPort() types.Int64           { return t.LocalPort }
+
 // setWebTargetAttributes populates the TF schema data from a web target API
 // object
 func setWebTargetAttributes(ctx context.Context, schema *webTargetModel, webTarget *targets.WebTarget) {
@@ -53,9 +70,48 @@ func setWebTargetAttributes(ctx context.Context, schema *webTargetModel, webTarg
 	target.SetBaseVirtualTargetAttributes(ctx, schema, webTarget)
 }
 
-func makeWebTargetDataSourceSchema(opts *target.BaseTargetDataSourceAttributeOptions) map[string]schema.Attribute {
+func makeWebTargetDataSourceSchema(opts *target.BaseTargetDataSourceAttributeOptions) map[string]datasource_schema.Attribute {
 	webTargetAttributes := target.BaseTargetDataSourceAttributes(targettype.Web, opts)
 	maps.Copy(webTargetAttributes, target.BaseVirtualTargetDataSourceAttributes(targettype.Web))
 
 	return webTargetAttributes
 }
+
+func makeWebTargetResourceSchema() map[string]resource_schema.Attribute {
+	webTargetAttributes := target.VirtualTargetResourceAttributes(targettype.Web)
+	webTargetAttributes["remote_host"] = resource_schema.StringAttribute{
+		Required:    true,
+		Description: "The target's hostname or IP address, including the scheme (e.g. `http://localhost`).",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+
+	return webTargetAttributes
+}
+
+func readWebTarget(ctx context.Context, schema *webTargetModel, client *bastionzero.Client) (found bool, diags diag.Diagnostics) {
+	if schema.ID.IsUnknown() || schema.ID.IsNull() {
+		diags.AddError(
+			"Unexpected null ID in schema",
+			"Expected ID to be set. Please report this issue to the provider developers.",
+		)
+		return false, diags
+	}
+
+	// Get refreshed web target value from BastionZero
+	tflog.Debug(ctx, "Querying for web target")
+	webTarget, _, err := client.Targets.GetWebTarget(ctx, schema.ID.ValueString())
+	if apierror.IsAPIErrorStatusCode(err, http.StatusNotFound) {
+		return false, diags
+	} else if err != nil {
+		diags.AddError(
+			"Error reading web target",
+			"Could not read web target, unexpected error: "+err.Error())
+		return false, diags
+	}
+	tflog.Debug(ctx, "Queried for web target")
+
+	setWebTargetAttributes(ctx, schema, webTarget)
+	return true, diags
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/users"
 	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzdatasource"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -24,8 +25,10 @@ func NewUsersDataSource() datasource.DataSource {
 			MarkdownDescription: "Get a list of all users in your BastionZero organization.",
 		},
 		ListAPIModels: func(ctx context.Context, client *bastionzero.Client) ([]users.User, error) {
-			users, _, err := client.Users.ListUsers(ctx)
-			return users, err
+			return listcache.List(ctx, listcache.KindUser, "", func(ctx context.Context) ([]users.User, error) {
+				users, _, err := client.Users.ListUsers(ctx)
+				return users, err
+			})
 		},
 	})
 }
@@ -0,0 +1,7 @@
+data "bastionzero_environment" "example" {
+  name = "example-env"
+  timeouts = {
+    # Wait up to 30 seconds to find environment with name "example-env"
+    read = "30s"
+  }
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+variable "env_id" {
+  type = string
+}
+
+data "bastionzero_environment_cleanup_preview" "example" {
+  environment_id = var.env_id
+}
+
+# Targets BastionZero will remove within the next day if they stay offline
+output "targets_removed_soon" {
+  value = [
+    for t in data.bastionzero_environment_cleanup_preview.example.offline_targets : t.name
+    if t.cleanup_time != null && timecmp(t.cleanup_time, timeadd(plantimestamp(), "24h")) < 0
+  ]
+}
\ No newline at end of file
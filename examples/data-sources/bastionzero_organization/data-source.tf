@@ -0,0 +1,5 @@
+data "bastionzero_organization" "current" {}
+
+output "organization_name" {
+  value = data.bastionzero_organization.current.name
+}
\ No newline at end of file
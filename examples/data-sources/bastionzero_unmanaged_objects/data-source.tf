@@ -0,0 +1,10 @@
+provider "bastionzero" {
+  ownership_marker = "[managed-by:terraform]"
+}
+
+data "bastionzero_unmanaged_objects" "example" {}
+
+# Names of policies that were not created by Terraform
+output "unmanaged_policy_names" {
+  value = [for each in data.bastionzero_unmanaged_objects.example.policies : each.name]
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+variable "bz_api_secret" {}
+
+# Append a hash-chained record of every change made to an environment, policy,
+# or db target to audit.log. Verify the log with:
+#
+#   go run ./tools/verifyauditlog audit.log
+provider "bastionzero" {
+  api_secret     = var.bz_api_secret
+  audit_log_path = "${path.root}/audit.log"
+}
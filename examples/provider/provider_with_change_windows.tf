@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+variable "bz_api_secret" {}
+
+# Only allow changes to BastionZero objects during business hours on weekdays.
+# Plans that would create, update, or delete an object at any other time fail.
+# Set read_only = true (or BASTIONZERO_READ_ONLY=true) to block all changes.
+provider "bastionzero" {
+  api_secret = var.bz_api_secret
+  change_windows = [
+    "Mon-Fri 09:00-17:00 America/New_York",
+  ]
+}
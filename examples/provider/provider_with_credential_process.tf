@@ -0,0 +1,16 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+# Obtain the API secret from an external command. The command must print
+# {"api_secret": "...", "expires_at": "..."} to stdout; `expires_at` is
+# optional. If it is set, the command is run again when the secret is about to
+# expire.
+provider "bastionzero" {
+  credential_process = "/usr/local/bin/bastionzero-secret --vault prod"
+}
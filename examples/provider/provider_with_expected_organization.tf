@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+variable "bz_api_secret" {}
+
+# Fail early if the API secret belongs to a different organization, and only
+# allow targets and policies to reference the staging environments
+provider "bastionzero" {
+  api_secret               = var.bz_api_secret
+  expected_organization_id = "00000000-0000-0000-0000-000000000000"
+  allowed_environment_ids = [
+    "11111111-1111-1111-1111-111111111111",
+  ]
+}
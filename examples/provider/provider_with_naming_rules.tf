@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+variable "bz_api_secret" {}
+
+# Require the names of environments, policies, and targets managed by
+# Terraform to start with "tf-" so they can be told apart in the web app
+provider "bastionzero" {
+  api_secret = var.bz_api_secret
+  naming_rules = {
+    pattern    = "^tf-[a-z0-9-]+$"
+    max_length = 64
+  }
+}
@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+# Read the API secret (and optionally the API endpoint) from the "staging"
+# profile in ~/.bastionzero/credentials. The profile can also be selected with
+# the BASTIONZERO_PROFILE environment variable.
+provider "bastionzero" {
+  profile = "staging"
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    bastionzero = {
+      source  = "bastionzero/bastionzero"
+      version = "~> 0.0"
+    }
+  }
+}
+
+variable "bz_api_secret" {}
+
+# Send API requests through a corporate egress proxy that re-signs TLS traffic
+# with a private CA
+provider "bastionzero" {
+  api_secret      = var.bz_api_secret
+  http_proxy      = "http://proxy.example.com:3128"
+  ca_cert_file    = "/etc/ssl/certs/corporate-ca.pem"
+  request_timeout = "2m"
+}
@@ -0,0 +1,11 @@
+resource "bastionzero_bzero_target" "example" {
+  name = "example-target"
+  # Delete the target from BastionZero when this resource is destroyed (e.g.
+  # when the instance running the agent is also destroyed)
+  delete_on_destroy = true
+  timeouts = {
+    # Wait up to 5 minutes for the target with name "example-target" to
+    # register
+    create = "5m"
+  }
+}
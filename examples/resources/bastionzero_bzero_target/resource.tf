@@ -0,0 +1,16 @@
+data "bastionzero_environments" "example" {}
+
+locals {
+  # Find environment with name "example-env". `env` is null if not found
+  env = one([
+    for each in data.bastionzero_environments.example.environments
+    : each if each.name == "example-env"
+  ])
+}
+
+# Adopt the Bzero target with name "example-target" once its agent registers,
+# and move it to the "example-env" environment
+resource "bastionzero_bzero_target" "example" {
+  name           = "example-target"
+  environment_id = local.env.id
+}
@@ -0,0 +1,20 @@
+data "bastionzero_environments" "example" {}
+
+locals {
+  # Find environment with name "example-env". `env` is null if not found
+  env = one([
+    for each in data.bastionzero_environments.example.environments
+    : each if each.name == "example-env"
+  ])
+}
+
+# Adopt the Cluster target with name "example-cluster" once the agent installed
+# by the Helm chart registers, and move it to the "example-env" environment
+resource "bastionzero_cluster_target" "example" {
+  name           = "example-cluster"
+  environment_id = local.env.id
+  timeouts = {
+    # Wait up to 10 minutes for the cluster to register
+    create = "10m"
+  }
+}
@@ -0,0 +1,17 @@
+data "bastionzero_environments" "example" {}
+
+locals {
+  # Find environment with name "example-env". `env` is null if not found
+  env = one([
+    for each in data.bastionzero_environments.example.environments
+    : each if each.name == "example-env"
+  ])
+}
+
+resource "bastionzero_dac_target" "example" {
+  name           = "example-dac"
+  environment_id = local.env.id
+  start_webhook  = "https://dat-provisioner.example.com/start"
+  stop_webhook   = "https://dat-provisioner.example.com/stop"
+  health_webhook = "https://dat-provisioner.example.com/health"
+}
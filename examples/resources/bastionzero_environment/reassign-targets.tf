@@ -0,0 +1,10 @@
+resource "bastionzero_environment" "fallback" {
+  name = "fallback-env"
+}
+
+# Move any targets left in this environment to the fallback environment when it
+# is destroyed
+resource "bastionzero_environment" "ephemeral" {
+  name                               = "ephemeral-env"
+  reassign_targets_to_environment_id = bastionzero_environment.fallback.id
+}
\ No newline at end of file
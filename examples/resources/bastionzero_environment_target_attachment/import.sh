@@ -0,0 +1,2 @@
+# Environment target attachment can be imported by specifying the unique identifier of the target.
+terraform import bastionzero_environment_target_attachment.example "8a6a3b7d-63a8-4d5a-8a4a-1f3f0d0e8a8b"
\ No newline at end of file
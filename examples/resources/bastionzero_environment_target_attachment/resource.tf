@@ -0,0 +1,14 @@
+data "bastionzero_bzero_target" "example" {
+  name = "example-target"
+}
+
+resource "bastionzero_environment" "example" {
+  name = "example-env"
+}
+
+# Move the target into the environment without managing the environment's
+# other targets
+resource "bastionzero_environment_target_attachment" "example" {
+  environment_id = bastionzero_environment.example.id
+  target_id      = data.bastionzero_bzero_target.example.id
+}
\ No newline at end of file
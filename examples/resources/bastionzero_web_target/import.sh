@@ -0,0 +1,2 @@
+# A Web target can be imported by specifying the unique identifier.
+terraform import bastionzero_web_target.example "01d7a020-2bbc-4ac0-b886-ac9e445d8ab1"
\ No newline at end of file
@@ -0,0 +1,20 @@
+data "bastionzero_environments" "example" {}
+
+locals {
+  # Find environment with name "example-env". `env` is null if not found
+  env = one([
+    for each in data.bastionzero_environments.example.environments
+    : each if each.name == "example-env"
+  ])
+}
+
+resource "bastionzero_web_target" "example" {
+  name                 = "example-web-target"
+  remote_host          = "http://localhost"
+  environment_id       = local.env.id
+  remote_port          = 8080
+  proxy_environment_id = local.env.id
+  # Configures the Web daemon to run on port 8080 when a user connects to this
+  # Web target
+  local_port = 8080
+}
\ No newline at end of file
@@ -0,0 +1,24 @@
+data "bastionzero_environments" "example" {}
+data "bastionzero_bzero_targets" "example" {}
+
+locals {
+  # Find environment with name "example-env". `env` is null if not found
+  env = one([
+    for each in data.bastionzero_environments.example.environments
+    : each if each.name == "example-env"
+  ])
+  # Find Linux or Windows target with name "ubuntu". `proxy_target` is null if
+  # not found
+  proxy_target = one([
+    for each in data.bastionzero_bzero_targets.example.targets
+    : each if each.name == "ubuntu"
+  ])
+}
+
+resource "bastionzero_web_target" "example" {
+  name            = "example-web-target"
+  remote_host     = "http://localhost"
+  environment_id  = local.env.id
+  remote_port     = 8080
+  proxy_target_id = local.proxy_target.id
+}
\ No newline at end of file
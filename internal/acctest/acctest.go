@@ -20,6 +20,7 @@ import (
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/targets"
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero/service/users"
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -99,17 +100,14 @@ func PreCheck(ctx context.Context, t *testing.T) {
 		schemaResponse := new(provider.SchemaResponse)
 		testProvider.Schema(ctx, provider.SchemaRequest{}, schemaResponse)
 
-		// Create empty config
+		// Create empty config where every provider attribute is null
+		configType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		nullAttributes := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+		for name, attributeType := range configType.AttributeTypes {
+			nullAttributes[name] = tftypes.NewValue(attributeType, nil)
+		}
 		testConfig := tfsdk.Config{
-			Raw: tftypes.NewValue(tftypes.Object{
-				AttributeTypes: map[string]tftypes.Type{
-					"api_endpoint": tftypes.String,
-					"api_secret":   tftypes.String,
-				},
-			}, map[string]tftypes.Value{
-				"api_endpoint": tftypes.NewValue(tftypes.String, nil),
-				"api_secret":   tftypes.NewValue(tftypes.String, nil),
-			}),
+			Raw:    tftypes.NewValue(configType, nullAttributes),
 			Schema: schemaResponse.Schema,
 		}
 
@@ -119,11 +117,11 @@ func PreCheck(ctx context.Context, t *testing.T) {
 		testProvider.Configure(ctx, provider.ConfigureRequest{Config: testConfig}, configureResponse)
 
 		// Parse the API client and save
-		apiClient, ok := configureResponse.ResourceData.(*bzapi.Client)
+		providerData, ok := configureResponse.ResourceData.(*providerdata.ProviderData)
 		if !ok {
-			t.Fatalf("expected provider to contain a *bastionzero.Client in its ResourceData")
+			t.Fatalf("expected provider to contain a *providerdata.ProviderData in its ResourceData")
 		}
-		APIClient = apiClient
+		APIClient = providerData.Client
 	})
 }
 
@@ -0,0 +1,373 @@
+// Package auditlog writes a local, tamper-evident record of the changes the
+// provider makes to BastionZero objects.
+//
+// The log is a file of JSON lines. Each line holds an Entry and the hash of
+// that entry. Every entry includes the hash of the entry before it, so editing,
+// reordering, or removing a line (other than the last lines) breaks the chain
+// and is detected by Verify.
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Action is the kind of change made to an object.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionAbandon is recorded when Terraform stops managing an object but
+	// leaves it in BastionZero.
+	ActionAbandon Action = "abandon"
+)
+
+// Caller identifies the credentials that made a change. The BastionZero API
+// client does not expose the subject that credentials belong to, so the caller
+// is identified by its organization and how the provider authenticated.
+type Caller struct {
+	OrganizationID   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name,omitempty"`
+	AuthMethod       string `json:"auth_method"`
+}
+
+// Change is the value of an attribute before and after a change. A nil value
+// means the attribute was null (or the object did not exist).
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Entry records a single change to a BastionZero object.
+type Entry struct {
+	Timestamp    time.Time         `json:"timestamp"`
+	Action       Action            `json:"action"`
+	ResourceType string            `json:"resource_type"`
+	ObjectID     string            `json:"object_id"`
+	Caller       Caller            `json:"caller"`
+	Changes      map[string]Change `json:"changes"`
+	PrevHash     string            `json:"prev_hash"`
+}
+
+// line is the format of a line in the log. Entry is kept as raw JSON so that
+// the hash is computed over exactly the bytes that were written.
+type line struct {
+	Entry json.RawMessage `json:"entry"`
+	Hash  string          `json:"hash"`
+}
+
+// hash returns the hash of the JSON encoded entry.
+func hash(entry []byte) string {
+	sum := sha256.Sum256(entry)
+	return hex.EncodeToString(sum[:])
+}
+
+// Logger appends entries to an audit log file. A nil *Logger records nothing.
+// It is safe for concurrent use.
+type Logger struct {
+	path   string
+	caller Caller
+
+	mu       sync.Mutex
+	lastHash string
+
+	now func() time.Time
+}
+
+// Open returns a logger that appends to the audit log at path, creating it if
+// it does not exist. The chain continues from the last entry already in the
+// file.
+func Open(path string, caller Caller) (*Logger, error) {
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lastHash, err := readLastHash(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return &Logger{
+		path:     path,
+		caller:   caller,
+		lastHash: lastHash,
+		now:      time.Now,
+	}, nil
+}
+
+// readLastHash returns the hash of the last entry in the log, or the empty
+// string if the log is empty.
+func readLastHash(r io.Reader) (string, error) {
+	var last []byte
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if last == nil {
+		return "", nil
+	}
+
+	var l line
+	if err := json.Unmarshal(last, &l); err != nil {
+		return "", fmt.Errorf("last line is not a valid audit log entry: %w", err)
+	}
+	return l.Hash, nil
+}
+
+// RecordCreate records the creation of an object whose state is after.
+func (l *Logger) RecordCreate(ctx context.Context, resourceType string, objectID string, after tfsdk.State) diag.Diagnostics {
+	return l.record(ctx, ActionCreate, resourceType, objectID, tftypes.Value{}, after.Raw)
+}
+
+// RecordUpdate records the update of an object from state before to state
+// after.
+func (l *Logger) RecordUpdate(ctx context.Context, resourceType string, objectID string, before, after tfsdk.State) diag.Diagnostics {
+	return l.record(ctx, ActionUpdate, resourceType, objectID, before.Raw, after.Raw)
+}
+
+// RecordDelete records the deletion of an object whose state was before.
+func (l *Logger) RecordDelete(ctx context.Context, resourceType string, objectID string, before tfsdk.State) diag.Diagnostics {
+	return l.record(ctx, ActionDelete, resourceType, objectID, before.Raw, tftypes.Value{})
+}
+
+// RecordAbandon records that an object whose state was before was removed from
+// Terraform without being deleted from BastionZero.
+func (l *Logger) RecordAbandon(ctx context.Context, resourceType string, objectID string, before tfsdk.State) diag.Diagnostics {
+	return l.record(ctx, ActionAbandon, resourceType, objectID, before.Raw, tftypes.Value{})
+}
+
+// record appends an entry to the log. The change has already been made by the
+// time it is recorded, so failures are returned as warnings rather than
+// failing (and tainting) the resource.
+func (l *Logger) record(ctx context.Context, action Action, resourceType string, objectID string, before, after tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if l == nil {
+		return diags
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Write BastionZero Audit Log",
+			fmt.Sprintf("The %s of %s %s was not recorded in the audit log: %s", action, resourceType, objectID, err),
+		)
+		return diags
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := Entry{
+		Timestamp:    l.now().UTC(),
+		Action:       action,
+		ResourceType: resourceType,
+		ObjectID:     objectID,
+		Caller:       l.caller,
+		Changes:      changes,
+		PrevHash:     l.lastHash,
+	}
+
+	newHash, err := l.append(entry)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Write BastionZero Audit Log",
+			fmt.Sprintf("The %s of %s %s was not recorded in the audit log at %s: %s", action, resourceType, objectID, l.path, err),
+		)
+		return diags
+	}
+	l.lastHash = newHash
+
+	tflog.Debug(ctx, "Recorded change in audit log", map[string]any{
+		"audit_log_path": l.path,
+		"action":         string(action),
+		"resource_type":  resourceType,
+		"object_id":      objectID,
+	})
+
+	return diags
+}
+
+// append writes entry to the end of the log and returns its hash.
+func (l *Logger) append(entry Entry) (string, error) {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	entryHash := hash(entryJSON)
+
+	lineJSON, err := json.Marshal(line{Entry: entryJSON, Hash: entryHash})
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(append(lineJSON, '\n')); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	return entryHash, nil
+}
+
+// Diff returns the top-level attributes of the objects before and after whose
+// values differ. A null value means the object does not exist.
+func Diff(before, after tftypes.Value) (map[string]Change, error) {
+	beforeAttrs, err := objectAttributes(before)
+	if err != nil {
+		return nil, err
+	}
+	afterAttrs, err := objectAttributes(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	addChange := func(name string) error {
+		b, a := beforeAttrs[name], afterAttrs[name]
+		if b.Type() != nil && a.Type() != nil && b.Equal(a) {
+			return nil
+		}
+		if isNull(b) && isNull(a) {
+			return nil
+		}
+
+		var change Change
+		var err error
+		if change.Before, err = toJSONValue(b); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+		if change.After, err = toJSONValue(a); err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+		changes[name] = change
+		return nil
+	}
+
+	for name := range beforeAttrs {
+		if err := addChange(name); err != nil {
+			return nil, err
+		}
+	}
+	for name := range afterAttrs {
+		if _, ok := beforeAttrs[name]; ok {
+			continue
+		}
+		if err := addChange(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// objectAttributes returns the attributes of the object v. It returns nil if v
+// is null.
+func objectAttributes(v tftypes.Value) (map[string]tftypes.Value, error) {
+	if isNull(v) {
+		return nil, nil
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+func isNull(v tftypes.Value) bool {
+	return v.Type() == nil || v.IsNull()
+}
+
+// toJSONValue converts v to a value that can be encoded as JSON.
+func toJSONValue(v tftypes.Value) (any, error) {
+	if isNull(v) {
+		return nil, nil
+	}
+	if !v.IsKnown() {
+		return nil, errors.New("value is unknown")
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			e, err := toJSONValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, e)
+		}
+		return out, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return nil, err
+		}
+		out := make(map[string]any, len(attrs))
+		for name, attr := range attrs {
+			a, err := toJSONValue(attr)
+			if err != nil {
+				return nil, err
+			}
+			out[name] = a
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
@@ -0,0 +1,122 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+var testObjectType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":       tftypes.String,
+	"name":     tftypes.String,
+	"duration": tftypes.Number,
+	"groups":   tftypes.Set{ElementType: tftypes.String},
+}}
+
+func testState(id, name string, duration int, groups ...string) tfsdk.State {
+	elems := make([]tftypes.Value, len(groups))
+	for i, g := range groups {
+		elems[i] = tftypes.NewValue(tftypes.String, g)
+	}
+	return tfsdk.State{Raw: tftypes.NewValue(testObjectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, id),
+		"name":     tftypes.NewValue(tftypes.String, name),
+		"duration": tftypes.NewValue(tftypes.Number, duration),
+		"groups":   tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems),
+	})}
+}
+
+func writeTestLog(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	logger, err := Open(path, Caller{OrganizationID: "org-1", OrganizationName: "Example", AuthMethod: "api_secret"})
+	require.NoError(t, err)
+	logger.now = func() time.Time { return time.Date(2023, 12, 4, 12, 0, 0, 0, time.UTC) }
+
+	require.False(t, logger.RecordCreate(ctx, "bastionzero_jit_policy", "p-1", testState("p-1", "jit", 60)).HasError())
+	require.False(t, logger.RecordUpdate(ctx, "bastionzero_jit_policy", "p-1", testState("p-1", "jit", 60), testState("p-1", "jit", 120, "admins")).HasError())
+	require.False(t, logger.RecordDelete(ctx, "bastionzero_jit_policy", "p-1", testState("p-1", "jit", 120, "admins")).HasError())
+
+	return path
+}
+
+func TestLogger(t *testing.T) {
+	path := writeTestLog(t)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[0], `"action":"create"`)
+	require.Contains(t, lines[0], `"caller":{"organization_id":"org-1","organization_name":"Example","auth_method":"api_secret"}`)
+	require.Contains(t, lines[0], `"duration":{"before":null,"after":60}`)
+	require.Contains(t, lines[1], `"changes":{"duration":{"before":60,"after":120},"groups":{"before":[],"after":["admins"]}}`)
+	require.Contains(t, lines[2], `"name":{"before":"jit","after":null}`)
+
+	count, err := Verify(bytes.NewReader(contents))
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	// Reopening the log continues the chain
+	logger, err := Open(path, Caller{OrganizationID: "org-1", AuthMethod: "api_secret"})
+	require.NoError(t, err)
+	require.False(t, logger.RecordCreate(context.Background(), "bastionzero_environment", "env-1", testState("env-1", "env", 0)).HasError())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	count, err = Verify(f)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+}
+
+func TestLogger_RecordAbandon(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := Open(path, Caller{OrganizationID: "org-1", AuthMethod: "api_secret"})
+	require.NoError(t, err)
+	require.False(t, logger.RecordAbandon(context.Background(), "bastionzero_bzero_target", "t-1", testState("t-1", "target", 0)).HasError())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"action":"abandon"`)
+	require.NotContains(t, string(contents), `"action":"delete"`)
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	contents, err := os.ReadFile(writeTestLog(t))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+
+	tests := []struct {
+		name  string
+		lines []string
+	}{
+		{name: "modified", lines: []string{lines[0], strings.Replace(lines[1], "admins", "everyone", 1), lines[2]}},
+		{name: "removed", lines: []string{lines[0], lines[2]}},
+		{name: "removed first", lines: []string{lines[1], lines[2]}},
+		{name: "reordered", lines: []string{lines[1], lines[0], lines[2]}},
+		{name: "garbage", lines: []string{lines[0], "not json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(strings.NewReader(strings.Join(tt.lines, "\n")))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLogger_Nil(t *testing.T) {
+	var logger *Logger
+	diags := logger.RecordCreate(context.Background(), "bastionzero_environment", "env-1", testState("env-1", "env", 0))
+	require.Empty(t, diags)
+}
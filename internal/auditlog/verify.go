@@ -0,0 +1,57 @@
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxLineSize is the maximum size of a line in the log.
+const maxLineSize = 16 * 1024 * 1024
+
+// Verify checks that the hash chain of the audit log read from r is intact and
+// returns the number of entries in the log. An error is returned if any entry
+// was modified, reordered, or removed. Removing entries from the end of the log
+// cannot be detected; compare the returned count (or the last hash) with a
+// previously verified copy to detect truncation.
+func Verify(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+
+	prevHash := ""
+	count := 0
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var l line
+		if err := json.Unmarshal(raw, &l); err != nil {
+			return count, fmt.Errorf("line %d: invalid audit log line: %w", lineNum, err)
+		}
+		if got := hash(l.Entry); got != l.Hash {
+			return count, fmt.Errorf("line %d: entry has been modified: hash is %s but entry hashes to %s", lineNum, l.Hash, got)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(l.Entry, &entry); err != nil {
+			return count, fmt.Errorf("line %d: invalid audit log entry: %w", lineNum, err)
+		}
+		if entry.PrevHash != prevHash {
+			return count, fmt.Errorf("line %d: chain is broken: previous hash is %s but the previous entry's hash is %q; an entry has been removed, reordered, or modified", lineNum, entry.PrevHash, prevHash)
+		}
+
+		prevHash = l.Hash
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
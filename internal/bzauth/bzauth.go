@@ -0,0 +1,49 @@
+// Package bzauth provides the credentials used by the provider to authenticate
+// requests sent to the BastionZero API.
+package bzauth
+
+import (
+	"net/http"
+)
+
+// Credential authenticates outgoing BastionZero API requests.
+type Credential interface {
+	// SetAuthHeader sets the header(s) used to authenticate the request. req
+	// is a clone of the original request and is safe to modify.
+	SetAuthHeader(req *http.Request) error
+}
+
+// Transport is an http.RoundTripper that authenticates every request it sends
+// using Credential.
+type Transport struct {
+	// Base is the underlying RoundTripper used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Credential authenticates each request.
+	Credential Credential
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Per the http.RoundTripper contract, the original request must not be
+	// modified
+	authReq := req.Clone(req.Context())
+	if err := t.Credential.SetAuthHeader(authReq); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	return t.base().RoundTrip(authReq)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,197 @@
+package bzauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APISecretHeader is the header used to authenticate BastionZero API requests
+// with an API secret.
+const APISecretHeader = "X-API-KEY"
+
+// credentialProcessTimeout bounds how long a single invocation of the
+// credential process may run.
+const credentialProcessTimeout = time.Minute
+
+// secretRefreshLeeway is how long before expiration a cached API secret is
+// replaced by running the credential process again. This avoids sending a
+// secret that expires while the request is in flight.
+const secretRefreshLeeway = time.Minute
+
+// CredentialProcessOutput is the JSON document a credential process must
+// print to stdout.
+type CredentialProcessOutput struct {
+	APISecret string `json:"api_secret"`
+	// ExpiresAt is optional. If set, the process is invoked again once the
+	// secret is about to expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CredentialProcess authenticates requests with an API secret obtained by
+// running an external command, e.g. a wrapper around a secrets manager CLI.
+//
+// The secret is cached and the command is only invoked again when the secret
+// is about to expire.
+type CredentialProcess struct {
+	args []string
+
+	// now is overridden in tests
+	now func() time.Time
+
+	mu        sync.Mutex
+	secret    string
+	expiresAt *time.Time
+
+	// secrets holds every API secret the process has returned so that they
+	// can all be masked in logs, including ones that have since expired but
+	// may still be in flight.
+	secretsMu sync.Mutex
+	secrets   []string
+}
+
+var _ Credential = &CredentialProcess{}
+
+// NewCredentialProcess returns a CredentialProcess that runs command. command
+// is split into arguments on whitespace; single and double quotes can be used
+// to group arguments containing whitespace. The command is not run by a shell.
+func NewCredentialProcess(command string) (*CredentialProcess, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("credential process command must not be empty")
+	}
+
+	return &CredentialProcess{
+		args: args,
+		now:  time.Now,
+	}, nil
+}
+
+// SetAuthHeader sets the API secret header to the current secret.
+func (c *CredentialProcess) SetAuthHeader(req *http.Request) error {
+	secret, err := c.APISecret(req.Context())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(APISecretHeader, secret)
+	return nil
+}
+
+// APISecret returns the API secret, running the credential process if there
+// is no cached secret or if the cached secret is about to expire.
+func (c *CredentialProcess) APISecret(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secret != "" && (c.expiresAt == nil || c.now().Add(secretRefreshLeeway).Before(*c.expiresAt)) {
+		return c.secret, nil
+	}
+
+	output, err := c.run(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.secret = output.APISecret
+	c.expiresAt = output.ExpiresAt
+
+	c.secretsMu.Lock()
+	if n := len(c.secrets); n == 0 || c.secrets[n-1] != c.secret {
+		c.secrets = append(c.secrets, c.secret)
+	}
+	c.secretsMu.Unlock()
+
+	return c.secret, nil
+}
+
+// Secrets returns every API secret the credential process has returned so
+// far. It does not run the process and does not block while it is running.
+func (c *CredentialProcess) Secrets() []string {
+	c.secretsMu.Lock()
+	defer c.secretsMu.Unlock()
+
+	return append([]string(nil), c.secrets...)
+}
+
+func (c *CredentialProcess) run(ctx context.Context) (*CredentialProcessOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.args[0], c.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := fmt.Sprintf("credential process %q failed: %s", c.args[0], err)
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			msg += ": " + s
+		}
+		return nil, errors.New(msg)
+	}
+
+	var output CredentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		// Do not include stdout in the error as it may contain the secret
+		return nil, fmt.Errorf("credential process %q returned invalid JSON: %w", c.args[0], err)
+	}
+	if output.APISecret == "" {
+		return nil, fmt.Errorf("credential process %q returned an empty api_secret", c.args[0])
+	}
+	if output.ExpiresAt != nil && !c.now().Before(*output.ExpiresAt) {
+		return nil, fmt.Errorf("credential process %q returned an api_secret that expired at %s", c.args[0], output.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return &output, nil
+}
+
+// splitCommand splits command into arguments on unquoted whitespace.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command: %s", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
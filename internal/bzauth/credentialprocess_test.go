@@ -0,0 +1,127 @@
+package bzauth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script that prints output and
+// appends a line to a counter file each time it is run.
+func writeScript(t *testing.T, output string) (script string, counter string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	script = filepath.Join(dir, "credential-process.sh")
+	counter = filepath.Join(dir, "invocations")
+	contents := fmt.Sprintf("#!/bin/sh\necho run >> %q\ncat <<'EOF'\n%s\nEOF\n", counter, output)
+	require.NoError(t, os.WriteFile(script, []byte(contents), 0700))
+	return script, counter
+}
+
+func invocations(t *testing.T, counter string) int {
+	data, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	n := 0
+	for _, b := range data {
+		if b == '\n' {
+			n++
+		}
+	}
+	return n
+}
+
+func TestCredentialProcess_NoExpiry(t *testing.T) {
+	script, counter := writeScript(t, `{"api_secret": "secret"}`)
+	process, err := NewCredentialProcess(script)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		secret, err := process.APISecret(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "secret", secret)
+	}
+	require.Equal(t, 1, invocations(t, counter))
+}
+
+func TestCredentialProcess_ReinvokedOnExpiry(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Minute).UTC()
+	script, counter := writeScript(t, fmt.Sprintf(`{"api_secret": "secret", "expires_at": %q}`, expiresAt.Format(time.RFC3339)))
+	process, err := NewCredentialProcess(script)
+	require.NoError(t, err)
+
+	now := time.Now()
+	process.now = func() time.Time { return now }
+
+	_, err = process.APISecret(context.Background())
+	require.NoError(t, err)
+	_, err = process.APISecret(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, invocations(t, counter))
+
+	// Within the refresh leeway of expiry
+	now = expiresAt.Add(-secretRefreshLeeway / 2)
+	_, err = process.APISecret(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, invocations(t, counter))
+
+	// Every distinct secret returned is kept for masking
+	require.Equal(t, []string{"secret"}, process.Secrets())
+}
+
+func TestCredentialProcess_Errors(t *testing.T) {
+	cases := map[string]string{
+		"invalid JSON":    `not json`,
+		"empty secret":    `{"api_secret": ""}`,
+		"already expired": `{"api_secret": "secret", "expires_at": "2000-01-01T00:00:00Z"}`,
+	}
+
+	for name, output := range cases {
+		t.Run(name, func(t *testing.T) {
+			script, _ := writeScript(t, output)
+			process, err := NewCredentialProcess(script)
+			require.NoError(t, err)
+
+			_, err = process.APISecret(context.Background())
+			require.Error(t, err)
+			require.NotContains(t, err.Error(), "secret\"")
+		})
+	}
+
+	t.Run("command fails", func(t *testing.T) {
+		process, err := NewCredentialProcess(filepath.Join(t.TempDir(), "does-not-exist"))
+		require.NoError(t, err)
+
+		_, err = process.APISecret(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestSplitCommand(t *testing.T) {
+	cases := []struct {
+		command  string
+		expected []string
+	}{
+		{command: "op read op://vault/bastionzero", expected: []string{"op", "read", "op://vault/bastionzero"}},
+		{command: `  vault  kv get   "-field=api secret"  `, expected: []string{"vault", "kv", "get", "-field=api secret"}},
+		{command: `cmd 'a "b"' ""`, expected: []string{"cmd", `a "b"`, ""}},
+		{command: "", expected: nil},
+	}
+
+	for _, tc := range cases {
+		args, err := splitCommand(tc.command)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, args, tc.command)
+	}
+
+	_, err := splitCommand(`cmd "unterminated`)
+	require.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package bzauth
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfileName is the name of the profile used when one is not
+// explicitly selected.
+const DefaultProfileName = "default"
+
+// ErrProfileNotFound is returned by LoadProfile when the credentials file does
+// not contain the requested profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named set of provider settings stored in a credentials file.
+type Profile struct {
+	APISecret   string `yaml:"api_secret"`
+	APIEndpoint string `yaml:"api_endpoint"`
+}
+
+// DefaultCredentialsFile returns the default location of the credentials file,
+// ~/.bastionzero/credentials.
+func DefaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".bastionzero", "credentials"), nil
+}
+
+// LoadProfile reads the credentials file at path and returns the profile with
+// the given name.
+//
+// The file is either in INI format, where each section is a profile:
+//
+//	[default]
+//	api_secret = ...
+//
+//	[prod]
+//	api_secret   = ...
+//	api_endpoint = https://cloud.bastionzero.com/
+//
+// or in YAML format, where each top-level key is a profile:
+//
+//	default:
+//	  api_secret: ...
+//	prod:
+//	  api_secret: ...
+//	  api_endpoint: https://cloud.bastionzero.com/
+//
+// If the file does not exist, the returned error wraps fs.ErrNotExist. If the
+// profile does not exist, the returned error wraps ErrProfileNotFound.
+func LoadProfile(path string, name string) (*Profile, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]Profile
+	if isINI(data) {
+		profiles, err = parseINIProfiles(data)
+	} else {
+		err = yaml.Unmarshal(data, &profiles)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in credentials file %s", ErrProfileNotFound, name, path)
+	}
+
+	return &profile, nil
+}
+
+// isINI reports whether the first line that is not blank or a comment is an
+// INI section header.
+func isINI(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		return strings.HasPrefix(line, "[")
+	}
+	return false
+}
+
+func parseINIProfiles(data []byte) (map[string]Profile, error) {
+	profiles := make(map[string]Profile)
+
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: malformed section header", lineNum)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			// Allow both "[prod]" and "[profile prod]"
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			if _, ok := profiles[section]; !ok {
+				profiles[section] = Profile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNum)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: key outside of a profile section", lineNum)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		profile := profiles[section]
+		switch key {
+		case "api_secret":
+			profile.APISecret = value
+		case "api_endpoint":
+			profile.APIEndpoint = value
+		default:
+			// Ignore unknown keys so that the file can be shared with other
+			// tools
+		}
+		profiles[section] = profile
+	}
+
+	return profiles, scanner.Err()
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,84 @@
+package bzauth
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeCredentialsFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoadProfile_INI(t *testing.T) {
+	path := writeCredentialsFile(t, `
+# BastionZero credentials
+[default]
+api_secret = default-secret
+
+[profile staging]
+api_secret   = "staging-secret"
+api_endpoint = https://staging.example.com/
+other_tool   = ignored
+`)
+
+	profile, err := LoadProfile(path, DefaultProfileName)
+	require.NoError(t, err)
+	require.Equal(t, &Profile{APISecret: "default-secret"}, profile)
+
+	profile, err = LoadProfile(path, "staging")
+	require.NoError(t, err)
+	require.Equal(t, &Profile{APISecret: "staging-secret", APIEndpoint: "https://staging.example.com/"}, profile)
+}
+
+func TestLoadProfile_YAML(t *testing.T) {
+	path := writeCredentialsFile(t, `
+# BastionZero credentials
+default:
+  api_secret: default-secret
+prod:
+  api_secret: prod-secret
+  api_endpoint: https://prod.example.com/
+`)
+
+	profile, err := LoadProfile(path, DefaultProfileName)
+	require.NoError(t, err)
+	require.Equal(t, &Profile{APISecret: "default-secret"}, profile)
+
+	profile, err = LoadProfile(path, "prod")
+	require.NoError(t, err)
+	require.Equal(t, &Profile{APISecret: "prod-secret", APIEndpoint: "https://prod.example.com/"}, profile)
+}
+
+func TestLoadProfile_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadProfile(filepath.Join(t.TempDir(), "does-not-exist"), DefaultProfileName)
+		require.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+
+	t.Run("missing profile", func(t *testing.T) {
+		path := writeCredentialsFile(t, "[default]\napi_secret = foo\n")
+		_, err := LoadProfile(path, "prod")
+		require.True(t, errors.Is(err, ErrProfileNotFound))
+	})
+
+	t.Run("malformed INI", func(t *testing.T) {
+		path := writeCredentialsFile(t, "[default]\napi_secret\n")
+		_, err := LoadProfile(path, DefaultProfileName)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrProfileNotFound))
+	})
+
+	t.Run("malformed YAML", func(t *testing.T) {
+		path := writeCredentialsFile(t, "default: [\n")
+		_, err := LoadProfile(path, DefaultProfileName)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrProfileNotFound))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -103,6 +104,7 @@ func NewListDataSource[T TFComputedModel, T2 APIModel](config *ListDataSourceCon
 	}
 
 	t := struct{ protoDataSource }{}
+	t.typeName = providerTypeName + "_" + config.MetadataTypeName
 	t.metadataFunc = func(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 		resp.TypeName = req.ProviderTypeName + fmt.Sprintf("_%s", config.MetadataTypeName)
 	}
@@ -140,16 +142,17 @@ func NewListDataSource[T TFComputedModel, T2 APIModel](config *ListDataSourceCon
 			return
 		}
 
-		client, ok := req.ProviderData.(*bastionzero.Client)
+		providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 		if !ok {
 			resp.Diagnostics.AddError(
 				"Unexpected Data Source configure type",
-				fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+				fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 			)
 			return
 		}
 
-		t.client = client
+		t.client = providerData.Client
+		t.listCache = providerData.ListCache
 	}
 	t.readFunc = func(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 		stateScaffold := struct {
@@ -247,6 +250,7 @@ func NewListDataSourceWithPractitionerParameters[T TFComputedModel, T2 TFNonComp
 	}
 
 	t := struct{ protoDataSource }{}
+	t.typeName = providerTypeName + "_" + config.MetadataTypeName
 	t.metadataFunc = func(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 		resp.TypeName = req.ProviderTypeName + fmt.Sprintf("_%s", config.MetadataTypeName)
 	}
@@ -286,16 +290,17 @@ func NewListDataSourceWithPractitionerParameters[T TFComputedModel, T2 TFNonComp
 			return
 		}
 
-		client, ok := req.ProviderData.(*bastionzero.Client)
+		providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 		if !ok {
 			resp.Diagnostics.AddError(
 				"Unexpected Data Source configure type",
-				fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+				fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 			)
 			return
 		}
 
-		t.client = client
+		t.client = providerData.Client
+		t.listCache = providerData.ListCache
 	}
 	t.readFunc = func(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 		var userParamsModel T2
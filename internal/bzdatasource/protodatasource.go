@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 )
 
@@ -11,11 +13,21 @@ import (
 //
 // Source: https://stackoverflow.com/a/31362378
 
+// providerTypeName is the provider's type name, which prefixes the type name
+// of every data source.
+const providerTypeName = "bastionzero"
+
 // Ensure prototype implements data source framework interface
 var _ datasource.DataSourceWithConfigure = &protoDataSource{}
 
 type protoDataSource struct {
 	client *bastionzero.Client
+	// listCache is made available to the data source's functions through
+	// the context passed to them. See listcache.FromContext.
+	listCache *listcache.Cache
+
+	// typeName is the data source's type name, used to label its spans
+	typeName string
 
 	metadataFunc  func(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse)
 	schemaFunc    func(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse)
@@ -33,5 +45,8 @@ func (p *protoDataSource) Configure(ctx context.Context, req datasource.Configur
 	p.configureFunc(ctx, req, resp)
 }
 func (p *protoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	p.readFunc(ctx, req, resp)
+	ctx, span := telemetry.StartDataSourceSpan(ctx, p.typeName)
+	defer telemetry.EndSpan(ctx, span, &resp.State, &resp.Diagnostics)
+
+	p.readFunc(listcache.NewContext(ctx, p.listCache), req, resp)
 }
@@ -3,14 +3,12 @@ package bzdatasource
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
-	"github.com/cenkalti/backoff/v4"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/bzretry"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/providerdata"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -95,6 +93,7 @@ func NewSingleDataSource[T TFSingleDataSourceModel, T2 APIModel](config *SingleD
 	}
 
 	t := struct{ protoDataSource }{}
+	t.typeName = providerTypeName + "_" + config.MetadataTypeName
 	t.metadataFunc = func(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 		resp.TypeName = req.ProviderTypeName + fmt.Sprintf("_%s", config.MetadataTypeName)
 	}
@@ -112,16 +111,17 @@ func NewSingleDataSource[T TFSingleDataSourceModel, T2 APIModel](config *SingleD
 			return
 		}
 
-		client, ok := req.ProviderData.(*bastionzero.Client)
+		providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 		if !ok {
 			resp.Diagnostics.AddError(
 				"Unexpected Data Source configure type",
-				fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+				fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 			)
 			return
 		}
 
-		t.client = client
+		t.client = providerData.Client
+		t.listCache = providerData.ListCache
 	}
 	t.readFunc = func(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 		var T T
@@ -187,6 +187,7 @@ func NewSingleDataSourceWithTimeout[T TFSingleDataSourceModel, T2 APIModel](conf
 	}
 
 	t := struct{ protoDataSource }{}
+	t.typeName = providerTypeName + "_" + config.MetadataTypeName
 	t.metadataFunc = func(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 		resp.TypeName = req.ProviderTypeName + fmt.Sprintf("_%s", config.MetadataTypeName)
 	}
@@ -208,16 +209,17 @@ func NewSingleDataSourceWithTimeout[T TFSingleDataSourceModel, T2 APIModel](conf
 			return
 		}
 
-		client, ok := req.ProviderData.(*bastionzero.Client)
+		providerData, ok := req.ProviderData.(*providerdata.ProviderData)
 		if !ok {
 			resp.Diagnostics.AddError(
 				"Unexpected Data Source configure type",
-				fmt.Sprintf("Expected *bastionzero.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+				fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 			)
 			return
 		}
 
-		t.client = client
+		t.client = providerData.Client
+		t.listCache = providerData.ListCache
 	}
 	t.readFunc = func(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 		var model T
@@ -255,44 +257,10 @@ func NewSingleDataSourceWithTimeout[T TFSingleDataSourceModel, T2 APIModel](conf
 			return
 		}
 
-		// Create linked child context that we can cancel under our own
-		// conditions in addition to the Terraform framework's context.
-		childCtx, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		// Spawn goroutine that listens for interrupts from user
-		go func() {
-			sigChan := make(chan os.Signal, 1)
-			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-			select {
-			case <-sigChan:
-				cancel()
-				return
-			case <-childCtx.Done():
-				// Must return to not leak this goroutine (in case no interrupt
-				// received)
-				return
-			}
-		}()
-
 		// Perform API call with backoff
-		backOffConfig := backoff.NewExponentialBackOff()
-		// Stop trying after timeout is hit
-		backOffConfig.MaxElapsedTime = readTimeout
-
-		apiObject, err := backoff.RetryNotifyWithData(
-			func() (*T2, error) {
-				apiObject, err := config.GetAPIModel(childCtx, model, t.client)
-				return apiObject, err
-			},
-			// Init backoff config with child context, so that we can cancel it
-			// due to interrupt
-			backoff.WithContext(backOffConfig, childCtx),
-			// Log message
-			func(err error, dur time.Duration) {
-				tflog.Info(ctx, fmt.Sprintf("%v. Retrying in %s...", err, dur))
-			},
-		)
+		apiObject, err := bzretry.Do(ctx, readTimeout, func(ctx context.Context) (*T2, error) {
+			return config.GetAPIModel(ctx, model, t.client)
+		})
 
 		// Error from API server, timeout, or context cancelled
 		if err != nil {
@@ -0,0 +1,237 @@
+package bzhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// redacted replaces the values of sensitive fields in logged bodies.
+	redacted = "***"
+
+	// maxLoggedBodySize is the size above which logged bodies are truncated.
+	maxLoggedBodySize = 64 * 1024
+)
+
+// sensitiveFieldSubstrings are the (lowercase) substrings of the names of JSON
+// fields and query parameters whose values are redacted from logged requests
+// and responses.
+var sensitiveFieldSubstrings = []string{
+	"secret",
+	"token",
+	"password",
+	"privatekey",
+	"private_key",
+	"credential",
+	"authorization",
+}
+
+// sensitiveFields are the (lowercase) names of JSON fields whose values are
+// redacted even though they do not look like secrets. The script returned by
+// the autodiscovery endpoints embeds a registration secret.
+var sensitiveFields = []string{
+	"script",
+}
+
+// LoggingTransport is an http.RoundTripper that logs each request's method,
+// URL, response status, and latency at the debug level with tflog. If
+// LogBodies is true, JSON request and response bodies are logged as well, with
+// the values of sensitive fields (e.g. secrets and tokens) redacted. Headers
+// are never logged.
+//
+// Log entries are written to the logger in the request's context, so they
+// appear alongside the entries of the resource or data source that sent the
+// request.
+type LoggingTransport struct {
+	// Base is the underlying RoundTripper used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// LogBodies enables logging of JSON request and response bodies.
+	LogBodies bool
+
+	// Secrets are masked wherever they appear in log entries, in addition to
+	// the values of sensitive fields.
+	Secrets []string
+
+	// SecretsFunc, if set, returns additional secrets to mask. It is called
+	// each time a request is logged, so it can supply secrets that change over
+	// the provider's lifetime (e.g. an API secret obtained again from a
+	// credential process after the previous one expired).
+	SecretsFunc func() []string
+}
+
+var _ http.RoundTripper = &LoggingTransport{}
+
+// NewLoggingTransport returns a LoggingTransport that wraps base.
+func NewLoggingTransport(base http.RoundTripper, logBodies bool, secrets ...string) *LoggingTransport {
+	return &LoggingTransport{Base: base, LogBodies: logBodies, Secrets: secrets}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_url":    redactURL(req),
+	}
+
+	if t.LogBodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_request_body"] = redactBody(req.Header.Get("Content-Type"), body)
+	}
+
+	start := time.Now()
+	resp, err := t.base().RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	// Collect the secrets to mask after the request is sent, as sending it may
+	// have obtained a new secret
+	ctx := t.maskSecrets(req.Context())
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "BastionZero API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status_code"] = resp.StatusCode
+	if t.LogBodies && resp.Body != nil && resp.Body != http.NoBody {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			fields["error"] = err.Error()
+			tflog.Debug(ctx, "BastionZero API request failed", fields)
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_response_body"] = redactBody(resp.Header.Get("Content-Type"), body)
+	}
+
+	tflog.Debug(ctx, "Sent BastionZero API request", fields)
+	return resp, nil
+}
+
+// maskSecrets returns a context whose logger masks the transport's secrets.
+func (t *LoggingTransport) maskSecrets(ctx context.Context) context.Context {
+	secrets := t.Secrets
+	if t.SecretsFunc != nil {
+		secrets = append(append([]string(nil), secrets...), t.SecretsFunc()...)
+	}
+	if secrets := nonEmpty(secrets); len(secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+	}
+	return ctx
+}
+
+func (t *LoggingTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// redactURL returns the URL of req with its user info removed and the values
+// of sensitive query parameters redacted.
+func redactURL(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if isSensitiveField(key) {
+				query[key] = []string{redacted}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// redactBody returns body as a string with the values of sensitive fields
+// redacted. Bodies that are not JSON cannot be redacted, so only their size is
+// returned.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	isJSON := mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+
+	var v any
+	if !isJSON || json.Unmarshal(body, &v) != nil {
+		return fmt.Sprintf("<%d byte non-JSON body omitted>", len(body))
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d byte body omitted>", len(body))
+	}
+	if len(out) > maxLoggedBodySize {
+		return string(out[:maxLoggedBodySize]) + "...<truncated>"
+	}
+	return string(out)
+}
+
+// redactValue replaces the values of sensitive fields in v, a value decoded by
+// encoding/json, recursively.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitiveField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isSensitiveField reports whether the value of the JSON field or query
+// parameter named name should be redacted.
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if name == field {
+			return true
+		}
+	}
+	for _, substr := range sensitiveFieldSubstrings {
+		if strings.Contains(name, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
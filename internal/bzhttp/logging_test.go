@@ -0,0 +1,141 @@
+package bzhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+)
+
+func newJSONServer(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// sendLogged sends a request through transport with a test logger and returns
+// the response body and the logged entries.
+func sendLogged(t *testing.T, transport http.RoundTripper, method, url, body string) (string, []map[string]any) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewBufferString(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	return string(respBody), entries
+}
+
+func TestLoggingTransport(t *testing.T) {
+	server := newJSONServer(t, http.StatusBadRequest, `{"errorMsg":"bad request"}`)
+
+	_, entries := sendLogged(t, NewLoggingTransport(nil, false), http.MethodPost, server.URL+"/api/v2/environments?token=abc&name=prod", `{"name":"prod"}`)
+
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	require.Equal(t, "debug", entry["@level"])
+	require.Equal(t, "POST", entry["http_method"])
+	require.Equal(t, server.URL+"/api/v2/environments?name=prod&token=%2A%2A%2A", entry["http_url"])
+	require.Equal(t, float64(http.StatusBadRequest), entry["http_status_code"])
+	require.Contains(t, entry, "http_duration_ms")
+	require.NotContains(t, entry, "http_request_body")
+	require.NotContains(t, entry, "http_response_body")
+}
+
+func TestLoggingTransport_Bodies(t *testing.T) {
+	server := newJSONServer(t, http.StatusOK, `{"script":"#!/bin/bash\nREGISTRATION_SECRET=abc","targetNameOption":"BashHostName"}`)
+
+	respBody, entries := sendLogged(t, NewLoggingTransport(nil, true), http.MethodPost, server.URL,
+		`{"apiSecret":"s3cr3t","nested":[{"idToken":"t0k3n","name":"prod"}]}`)
+
+	// The caller still receives the unredacted response
+	require.Contains(t, respBody, "REGISTRATION_SECRET=abc")
+
+	require.Len(t, entries, 1)
+	require.Equal(t, `{"apiSecret":"***","nested":[{"idToken":"***","name":"prod"}]}`, entries[0]["http_request_body"])
+	require.Equal(t, `{"script":"***","targetNameOption":"BashHostName"}`, entries[0]["http_response_body"])
+}
+
+func TestLoggingTransport_Secrets(t *testing.T) {
+	server := newJSONServer(t, http.StatusOK, `{"message":"hello s3cr3t"}`)
+
+	_, entries := sendLogged(t, NewLoggingTransport(nil, true, "s3cr3t", ""), http.MethodGet, server.URL, "")
+
+	require.Len(t, entries, 1)
+	require.Equal(t, `{"message":"hello ***"}`, entries[0]["http_response_body"])
+}
+
+func TestLoggingTransport_SecretsFunc(t *testing.T) {
+	server := newJSONServer(t, http.StatusOK, `{"message":"hello s3cr3t and r0tat3d"}`)
+
+	secrets := []string{"s3cr3t"}
+	transport := NewLoggingTransport(nil, true)
+	transport.SecretsFunc = func() []string { return secrets }
+
+	// Secrets added after the transport is created are masked too
+	secrets = append(secrets, "r0tat3d")
+	_, entries := sendLogged(t, transport, http.MethodGet, server.URL, "")
+
+	require.Len(t, entries, 1)
+	require.Equal(t, `{"message":"hello *** and ***"}`, entries[0]["http_response_body"])
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		"json": {
+			contentType: "application/json",
+			body:        `{"refresh_token":"x","password":"y","privateKey":"z","id":"1"}`,
+			expected:    `{"id":"1","password":"***","privateKey":"***","refresh_token":"***"}`,
+		},
+		"json suffix": {
+			contentType: "application/problem+json",
+			body:        `{"detail":"x"}`,
+			expected:    `{"detail":"x"}`,
+		},
+		"not json": {
+			contentType: "text/plain",
+			body:        "secret",
+			expected:    "<6 byte non-JSON body omitted>",
+		},
+		"invalid json": {
+			contentType: "application/json",
+			body:        "{",
+			expected:    "<1 byte non-JSON body omitted>",
+		},
+		"empty": {
+			contentType: "application/json",
+			expected:    "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, redactBody(tc.contentType, []byte(tc.body)))
+		})
+	}
+}
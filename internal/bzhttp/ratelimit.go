@@ -0,0 +1,112 @@
+package bzhttp
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitTransport is an http.RoundTripper that limits the rate of requests
+// and the number of requests in flight. A single RateLimitTransport should be
+// shared by all users of the API client so that they draw from the same
+// budget.
+type RateLimitTransport struct {
+	// Base is the underlying RoundTripper used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// limiter is nil if the request rate is unlimited
+	limiter *rate.Limiter
+	// slots is nil if the number of concurrent requests is unlimited
+	slots chan struct{}
+}
+
+var _ http.RoundTripper = &RateLimitTransport{}
+
+// NewRateLimitTransport returns a RateLimitTransport that wraps base.
+//
+// If requestsPerSecond is greater than zero, requests are sent at no more than
+// that rate with a burst of up to one second's worth of requests. If
+// maxConcurrent is greater than zero, no more than maxConcurrent requests are
+// in flight at once; a request is in flight until its response body is
+// closed.
+func NewRateLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *RateLimitTransport {
+	t := &RateLimitTransport{Base: base}
+	if requestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Ceil(requestsPerSecond)))
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			closeRequestBody(req)
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			t.release()
+			closeRequestBody(req)
+			return nil, err
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	if t.slots != nil {
+		resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: t.release}
+	}
+	return resp, nil
+}
+
+func (t *RateLimitTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releaseOnClose releases a concurrency slot the first time the response body
+// is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
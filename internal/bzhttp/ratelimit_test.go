@@ -0,0 +1,80 @@
+package bzhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransport_RequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// Burst of 5 followed by 5 more at 5 requests per second
+	transport := NewRateLimitTransport(nil, 5, 0)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		resp := doRequest(t, transport, http.MethodGet, server.URL, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 800*time.Millisecond)
+}
+
+func TestRateLimitTransport_MaxConcurrent(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := NewRateLimitTransport(nil, 0, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doRequest(t, transport, http.MethodGet, server.URL, nil)
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	require.Len(t, transport.slots, 0, "all slots should be released")
+}
+
+func TestRateLimitTransport_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	transport := NewRateLimitTransport(nil, 0, 1)
+	// Occupy the only slot
+	transport.slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: transport}).Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimitTransport_Unlimited(t *testing.T) {
+	transport := NewRateLimitTransport(nil, 0, 0)
+	require.Nil(t, transport.limiter)
+	require.Nil(t, transport.slots)
+}
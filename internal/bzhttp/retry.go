@@ -0,0 +1,232 @@
+// Package bzhttp provides the http.RoundTripper middleware used by the
+// provider's BastionZero API client.
+package bzhttp
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried.
+	DefaultMaxRetries = 4
+
+	// DefaultRetryMaxWait is the default maximum time to wait between
+	// retries.
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// RetryTransport is an http.RoundTripper that retries requests that fail due
+// to throttling or transient server errors.
+//
+// Idempotent requests (GET, HEAD, OPTIONS, PUT, and DELETE) are retried on 429,
+// 502, 503, and 504 responses and on network errors. Other requests (e.g. POST
+// and PATCH) are only retried on 429 responses, as the API rejects throttled
+// requests before processing them; retrying them on other failures could
+// create duplicate objects.
+//
+// A Retry-After header on the response is honored. If the server asks to wait
+// longer than MaxWait, the response is returned without retrying.
+type RetryTransport struct {
+	// Base is the underlying RoundTripper used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// MaxRetries is the maximum number of times a request is retried. Zero
+	// disables retries.
+	MaxRetries int
+
+	// MaxWait is the maximum time to wait between attempts.
+	MaxWait time.Duration
+
+	// newBackOff is overridden in tests
+	newBackOff func() backoff.BackOff
+}
+
+var _ http.RoundTripper = &RetryTransport{}
+
+// NewRetryTransport returns a RetryTransport that wraps base.
+func NewRetryTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) *RetryTransport {
+	return &RetryTransport{
+		Base:       base,
+		MaxRetries: maxRetries,
+		MaxWait:    maxWait,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	b := t.backOff()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base().RoundTrip(attemptReq)
+		if attempt >= t.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := b.NextBackOff()
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			if retryAfter > t.MaxWait {
+				// Honoring the server's request would exceed our budget
+				return resp, err
+			}
+			wait = retryAfter
+		}
+		if wait == backoff.Stop {
+			return resp, err
+		}
+
+		logFields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			logFields["error"] = err.Error()
+		} else {
+			logFields["status_code"] = resp.StatusCode
+		}
+		tflog.Warn(ctx, "Retrying BastionZero API request", logFields)
+
+		// Record the retry on the request's span, if it is being traced
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(telemetry.HTTPResendCountKey.Int(attempt + 1))
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("attempt", attempt+1),
+			attribute.String("wait", wait.String()),
+		))
+
+		// Drain and close the body so the connection can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RetryTransport) backOff() backoff.BackOff {
+	if t.newBackOff != nil {
+		return t.newBackOff()
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = t.MaxWait
+	// The number of attempts is bounded by MaxRetries instead
+	b.MaxElapsedTime = 0
+	b.Reset()
+	return b
+}
+
+// shouldRetry reports whether req should be sent again given the result of
+// the previous attempt.
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	// The body cannot be re-sent
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	idempotent := isIdempotent(req.Method)
+	if err != nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewindRequest returns a clone of req with a fresh body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone.Body = body
+	return clone, nil
+}
+
+// parseRetryAfter parses the Retry-After header of resp, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
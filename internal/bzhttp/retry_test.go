@@ -0,0 +1,143 @@
+package bzhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRetryTransport returns a RetryTransport that does not wait between
+// attempts unless told to by Retry-After.
+func newTestRetryTransport(maxRetries int, maxWait time.Duration) *RetryTransport {
+	t := NewRetryTransport(nil, maxRetries, maxWait)
+	t.newBackOff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+	return t
+}
+
+// newStatusServer returns a server that responds with the given status codes
+// in order, followed by 200 OK, and records the bodies it received.
+func newStatusServer(t *testing.T, statuses []int, headers map[string]string) (*httptest.Server, *int32, *[]string) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if int(n) <= len(statuses) {
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func doRequest(t *testing.T, transport http.RoundTripper, method, url string, body []byte) *http.Response {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestRetryTransport_RetriesIdempotentRequests(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		server, calls, _ := newStatusServer(t, []int{status, status}, nil)
+		resp := doRequest(t, newTestRetryTransport(4, time.Second), http.MethodGet, server.URL, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.EqualValues(t, 3, *calls)
+	}
+}
+
+func TestRetryTransport_StopsAfterMaxRetries(t *testing.T) {
+	server, calls, _ := newStatusServer(t, []int{503, 503, 503, 503}, nil)
+	resp := doRequest(t, newTestRetryTransport(2, time.Second), http.MethodDelete, server.URL, nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.EqualValues(t, 3, *calls)
+}
+
+func TestRetryTransport_NonIdempotentRequests(t *testing.T) {
+	// Not retried on 503 as the request may have been processed
+	server, calls, _ := newStatusServer(t, []int{503}, nil)
+	resp := doRequest(t, newTestRetryTransport(4, time.Second), http.MethodPost, server.URL, []byte(`{"name":"foo"}`))
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.EqualValues(t, 1, *calls)
+
+	// Retried on 429 with the same body
+	server, calls, bodies := newStatusServer(t, []int{429}, nil)
+	resp = doRequest(t, newTestRetryTransport(4, time.Second), http.MethodPost, server.URL, []byte(`{"name":"foo"}`))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 2, *calls)
+	require.Equal(t, []string{`{"name":"foo"}`, `{"name":"foo"}`}, *bodies)
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	server, calls, _ := newStatusServer(t, []int{429}, map[string]string{"Retry-After": "1"})
+	start := time.Now()
+	resp := doRequest(t, newTestRetryTransport(4, 5*time.Second), http.MethodGet, server.URL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 2, *calls)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// Retry-After exceeds the maximum wait
+	server, calls, _ = newStatusServer(t, []int{429}, map[string]string{"Retry-After": "120"})
+	resp = doRequest(t, newTestRetryTransport(4, 5*time.Second), http.MethodGet, server.URL, nil)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.EqualValues(t, 1, *calls)
+}
+
+func TestRetryTransport_ContextCanceled(t *testing.T) {
+	server, _, _ := newStatusServer(t, []int{429}, map[string]string{"Retry-After": "10"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: newTestRetryTransport(4, time.Minute)}).Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		header string
+		ok     bool
+		min    time.Duration
+		max    time.Duration
+	}{
+		{header: "", ok: false},
+		{header: "foo", ok: false},
+		{header: "-1", ok: false},
+		{header: "5", ok: true, min: 5 * time.Second, max: 5 * time.Second},
+		{header: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), ok: true, min: 0, max: 0},
+		{header: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), ok: true, min: 58 * time.Second, max: time.Minute},
+	}
+
+	for _, tc := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", tc.header)
+
+		wait, ok := parseRetryAfter(resp)
+		require.Equal(t, tc.ok, ok, tc.header)
+		require.GreaterOrEqual(t, wait, tc.min, tc.header)
+		require.LessOrEqual(t, wait, tc.max, tc.header)
+	}
+}
@@ -0,0 +1,70 @@
+package bzhttp
+
+import (
+	"net/http"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingTransport is an http.RoundTripper that records each request as an
+// OpenTelemetry span. It should wrap the RetryTransport so that a request and
+// all of its retries are recorded as one span.
+type TracingTransport struct {
+	// Base is the underlying RoundTripper used to send requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// tracer is overridden in tests
+	tracer trace.Tracer
+}
+
+var _ http.RoundTripper = &TracingTransport{}
+
+// NewTracingTransport returns a TracingTransport that wraps base.
+func NewTracingTransport(base http.RoundTripper) *TracingTransport {
+	return &TracingTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracer := t.tracer
+	if tracer == nil {
+		tracer = telemetry.Tracer()
+	}
+
+	// Omit the query string as it may contain identifying information
+	u := *req.URL
+	u.RawQuery = ""
+	u.User = nil
+
+	ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			telemetry.HTTPRequestMethodKey.String(req.Method),
+			telemetry.URLFullKey.String(u.String()),
+			telemetry.ServerAddressKey.String(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(telemetry.HTTPResponseStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package bzhttp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestTracingTransport(base http.RoundTripper) (*TracingTransport, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t := NewTracingTransport(base)
+	t.tracer = tp.Tracer("test")
+	return t, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingTransport(t *testing.T) {
+	server, _, _ := newStatusServer(t, nil, nil)
+	transport, recorder := newTestTracingTransport(nil)
+
+	doRequest(t, transport, http.MethodGet, server.URL+"/api/v2/environments?name=secret", nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "HTTP GET", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	attrs := spanAttributes(spans[0])
+	require.Equal(t, "GET", attrs[telemetry.HTTPRequestMethodKey].AsString())
+	require.Equal(t, server.URL+"/api/v2/environments", attrs[telemetry.URLFullKey].AsString())
+	require.Equal(t, int64(http.StatusOK), attrs[telemetry.HTTPResponseStatusCodeKey].AsInt64())
+	require.NotContains(t, attrs, telemetry.HTTPResendCountKey)
+}
+
+func TestTracingTransport_Retries(t *testing.T) {
+	server, _, _ := newStatusServer(t, []int{http.StatusServiceUnavailable, http.StatusBadGateway}, nil)
+	transport, recorder := newTestTracingTransport(newTestRetryTransport(4, time.Second))
+
+	doRequest(t, transport, http.MethodGet, server.URL, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	attrs := spanAttributes(spans[0])
+	require.Equal(t, int64(2), attrs[telemetry.HTTPResendCountKey].AsInt64())
+	require.Equal(t, int64(http.StatusOK), attrs[telemetry.HTTPResponseStatusCodeKey].AsInt64())
+	require.Len(t, spans[0].Events(), 2)
+}
+
+func TestTracingTransport_ErrorStatus(t *testing.T) {
+	server, _, _ := newStatusServer(t, []int{http.StatusNotFound}, nil)
+	transport, recorder := newTestTracingTransport(nil)
+
+	doRequest(t, transport, http.MethodGet, server.URL, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, int64(http.StatusNotFound), spanAttributes(spans[0])[telemetry.HTTPResponseStatusCodeKey].AsInt64())
+}
@@ -0,0 +1,69 @@
+package bzhttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig configures the base transport created by NewTransport.
+type TransportConfig struct {
+	// CACertPEM contains additional PEM encoded CA certificates trusted when
+	// verifying the API's certificate. The system roots are trusted as well.
+	CACertPEM []byte
+
+	// InsecureSkipVerify disables verification of the API's certificate.
+	InsecureSkipVerify bool
+
+	// ProxyURL is the proxy used for all requests. If nil, the proxy is read
+	// from the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables.
+	ProxyURL *url.URL
+
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded certificate and
+	// private key presented for mutual TLS. Both or neither must be set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+}
+
+// NewTransport returns a new http.Transport with the same defaults as
+// http.DefaultTransport, customized according to config.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(config.ProxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if len(config.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CACertPEM) {
+			return nil, errors.New("no valid PEM encoded CA certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.ClientCertPEM) > 0 || len(config.ClientKeyPEM) > 0 {
+		if len(config.ClientCertPEM) == 0 || len(config.ClientKeyPEM) == 0 {
+			return nil, errors.New("both a client certificate and a client key must be provided")
+		}
+		cert, err := tls.X509KeyPair(config.ClientCertPEM, config.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
@@ -0,0 +1,87 @@
+package bzhttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func certPEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// The test server's certificate is not trusted by default
+	transport, err := NewTransport(TransportConfig{})
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err)
+
+	transport, err = NewTransport(TransportConfig{CACertPEM: certPEM(server.Certificate())})
+	require.NoError(t, err)
+	resp := doRequest(t, transport, http.MethodGet, server.URL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	transport, err = NewTransport(TransportConfig{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	resp = doRequest(t, transport, http.MethodGet, server.URL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = NewTransport(TransportConfig{CACertPEM: []byte("foo")})
+	require.Error(t, err)
+}
+
+func TestNewTransport_ClientCert(t *testing.T) {
+	// Reuse the test server's certificate as the client certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	serverCert := server.TLS.Certificates[0]
+	keyDER, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	transport, err := NewTransport(TransportConfig{
+		CACertPEM:     certPEM(server.Certificate()),
+		ClientCertPEM: certPEM(server.Certificate()),
+		ClientKeyPEM:  keyPEM,
+	})
+	require.NoError(t, err)
+	resp := doRequest(t, transport, http.MethodGet, server.URL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = NewTransport(TransportConfig{ClientCertPEM: certPEM(server.Certificate())})
+	require.Error(t, err)
+
+	_, err = NewTransport(TransportConfig{ClientCertPEM: []byte("foo"), ClientKeyPEM: []byte("bar")})
+	require.Error(t, err)
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.example.com:3128")
+	require.NoError(t, err)
+
+	transport, err := NewTransport(TransportConfig{ProxyURL: proxyURL})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://cloud.bastionzero.com/", nil)
+	require.NoError(t, err)
+	got, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, proxyURL, got)
+}
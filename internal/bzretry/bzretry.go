@@ -0,0 +1,64 @@
+// Package bzretry retries calls to the BastionZero API that are expected to
+// succeed eventually, e.g. looking up a target that is still registering.
+package bzretry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Do calls get with exponential backoff until it returns without error, it
+// returns an error of type backoff.PermanentError, timeout has elapsed, or the
+// user interrupts Terraform. Retries are passed a context that refreshes
+// cached lists (see listcache.WithRefresh), as a stale list may be what is
+// missing the object.
+func Do[T any](ctx context.Context, timeout time.Duration, get func(ctx context.Context) (*T, error)) (*T, error) {
+	// Create linked child context that we can cancel under our own conditions
+	// in addition to the Terraform framework's context.
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Spawn goroutine that listens for interrupts from user
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+		select {
+		case <-sigChan:
+			cancel()
+			return
+		case <-childCtx.Done():
+			// Must return to not leak this goroutine (in case no interrupt
+			// received)
+			return
+		}
+	}()
+
+	backOffConfig := backoff.NewExponentialBackOff()
+	// Stop trying after timeout is hit
+	backOffConfig.MaxElapsedTime = timeout
+
+	attemptCtx := childCtx
+	return backoff.RetryNotifyWithData(
+		func() (*T, error) {
+			result, err := get(attemptCtx)
+			attemptCtx = listcache.WithRefresh(childCtx)
+			return result, err
+		},
+		// Init backoff config with child context, so that we can cancel it due
+		// to interrupt
+		backoff.WithContext(backOffConfig, childCtx),
+		// Log message
+		func(err error, dur time.Duration) {
+			tflog.Info(ctx, fmt.Sprintf("%v. Retrying in %s...", err, dur))
+		},
+	)
+}
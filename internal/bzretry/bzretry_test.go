@@ -0,0 +1,55 @@
+package bzretry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo_RetriesWithRefreshedLists(t *testing.T) {
+	ctx := listcache.NewContext(context.Background(), listcache.New(time.Minute))
+	var fetches int32
+
+	result, err := Do(ctx, time.Minute, func(ctx context.Context) (*int32, error) {
+		list, err := listcache.List(ctx, listcache.KindEnvironment, "", func(context.Context) ([]int32, error) {
+			return []int32{atomic.AddInt32(&fetches, 1)}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// The object only appears in the second list
+		if list[0] < 2 {
+			return nil, errors.New("not found")
+		}
+		return &list[0], nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int32(2), *result)
+}
+
+func TestDo_PermanentError(t *testing.T) {
+	calls := 0
+
+	_, err := Do(context.Background(), time.Minute, func(context.Context) (*int, error) {
+		calls++
+		return nil, backoff.Permanent(errors.New("forbidden"))
+	})
+
+	require.EqualError(t, err, "forbidden")
+	require.Equal(t, 1, calls)
+}
+
+func TestDo_Timeout(t *testing.T) {
+	_, err := Do(context.Background(), time.Millisecond, func(context.Context) (*int, error) {
+		return nil, errors.New("not found")
+	})
+
+	require.EqualError(t, err, "not found")
+}
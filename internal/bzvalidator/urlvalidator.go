@@ -0,0 +1,61 @@
+package bzvalidator
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = urlValidator{}
+
+// urlValidator validates that a string Attribute's value is a valid absolute
+// HTTP or HTTPS URL
+type urlValidator struct{}
+
+// Description describes the validation in plain text formatting.
+func (validator urlValidator) Description(_ context.Context) string {
+	return "value must be a valid absolute URL with an http or https scheme"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (validator urlValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+// Validate performs the validation.
+func (v urlValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+
+	if !isValidURL(value) {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			value,
+		))
+	}
+}
+
+// ValidURL returns an AttributeValidator which ensures that any configured
+// attribute value:
+//
+//   - Is a string.
+//   - Is a valid absolute URL with an http or https scheme and a host.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func ValidURL() validator.String {
+	return urlValidator{}
+}
+
+func isValidURL(u string) bool {
+	parsed, err := url.ParseRequestURI(u)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,183 @@
+package guardrail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the IANA time zone database so that change window time zones
+	// resolve even on systems without one (e.g. Windows)
+	_ "time/tzdata"
+)
+
+// ChangeWindow is a period of time during which changes are allowed.
+type ChangeWindow interface {
+	// Contains reports whether t is within the window.
+	Contains(t time.Time) bool
+	// String returns the window in the format it was parsed from.
+	String() string
+}
+
+// ParseChangeWindow parses a change window in one of the following formats:
+//
+//   - A fixed time range of two RFC 3339 timestamps separated by a slash,
+//     e.g. "2023-12-01T09:00:00Z/2023-12-01T17:00:00Z".
+//   - A recurring weekly schedule of days and a time range, optionally
+//     followed by an IANA time zone (defaults to UTC), e.g. "Mon-Fri
+//     09:00-17:00 America/New_York". Days are a comma separated list of days
+//     or day ranges (e.g. "Mon,Wed,Fri" or "Sat-Sun"), or "*" for every day.
+//     If the end time is before the start time, the window ends on the
+//     following day.
+func ParseChangeWindow(s string) (ChangeWindow, error) {
+	s = strings.TrimSpace(s)
+
+	// Time zone names contain slashes too, but never with the whitespace that
+	// separates the fields of a recurring window
+	if start, end, ok := strings.Cut(s, "/"); ok && !strings.ContainsAny(s, " \t") {
+		return parseFixedWindow(s, start, end)
+	}
+
+	return parseRecurringWindow(s)
+}
+
+// fixedWindow is a single time range.
+type fixedWindow struct {
+	raw        string
+	start, end time.Time
+}
+
+func parseFixedWindow(raw, start, end string) (*fixedWindow, error) {
+	startTime, err := time.Parse(time.RFC3339, strings.TrimSpace(start))
+	if err != nil {
+		return nil, fmt.Errorf("invalid change window %q: start is not an RFC 3339 timestamp: %w", raw, err)
+	}
+	endTime, err := time.Parse(time.RFC3339, strings.TrimSpace(end))
+	if err != nil {
+		return nil, fmt.Errorf("invalid change window %q: end is not an RFC 3339 timestamp: %w", raw, err)
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("invalid change window %q: end must be after start", raw)
+	}
+
+	return &fixedWindow{raw: raw, start: startTime, end: endTime}, nil
+}
+
+func (w *fixedWindow) Contains(t time.Time) bool {
+	return !t.Before(w.start) && t.Before(w.end)
+}
+
+func (w *fixedWindow) String() string { return w.raw }
+
+// recurringWindow is a time range repeated on certain days of the week.
+type recurringWindow struct {
+	raw      string
+	days     [7]bool
+	start    time.Duration // offset from midnight
+	end      time.Duration // offset from midnight
+	location *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func parseRecurringWindow(raw string) (*recurringWindow, error) {
+	fields := strings.Fields(raw)
+	if len(fields) != 2 && len(fields) != 3 {
+		return nil, fmt.Errorf("invalid change window %q: expected \"<days> <HH:MM>-<HH:MM> [time zone]\" or \"<RFC 3339 start>/<RFC 3339 end>\"", raw)
+	}
+
+	w := &recurringWindow{raw: raw, location: time.UTC}
+
+	if fields[0] == "*" {
+		for i := range w.days {
+			w.days[i] = true
+		}
+	} else {
+		for _, part := range strings.Split(fields[0], ",") {
+			first, last, isRange := strings.Cut(part, "-")
+			if !isRange {
+				last = first
+			}
+			firstDay, ok := weekdays[strings.ToLower(first)]
+			if !ok {
+				return nil, fmt.Errorf("invalid change window %q: unknown day %q", raw, first)
+			}
+			lastDay, ok := weekdays[strings.ToLower(last)]
+			if !ok {
+				return nil, fmt.Errorf("invalid change window %q: unknown day %q", raw, last)
+			}
+			// Ranges may wrap around the end of the week (e.g. Fri-Mon)
+			for d := firstDay; ; d = (d + 1) % 7 {
+				w.days[d] = true
+				if d == lastDay {
+					break
+				}
+			}
+		}
+	}
+
+	start, end, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid change window %q: expected a time range such as 09:00-17:00", raw)
+	}
+	var err error
+	if w.start, err = parseClock(start); err != nil {
+		return nil, fmt.Errorf("invalid change window %q: %w", raw, err)
+	}
+	if w.end, err = parseClock(end); err != nil {
+		return nil, fmt.Errorf("invalid change window %q: %w", raw, err)
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("invalid change window %q: start and end times must differ", raw)
+	}
+
+	if len(fields) == 3 {
+		if w.location, err = time.LoadLocation(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid change window %q: unknown time zone %q", raw, fields[2])
+		}
+	}
+
+	return w, nil
+}
+
+// parseClock parses a time of day in HH:MM format. 24:00 is allowed to denote
+// the end of the day.
+func parseClock(s string) (time.Duration, error) {
+	var hour, minute int
+	if n, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil || n != 2 || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time of day %q: expected HH:MM", s)
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+func (w *recurringWindow) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	// Use the wall clock time rather than the time elapsed since midnight,
+	// which is off by the shift on daylight saving time transition days
+	offset := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+
+	if w.start < w.end {
+		return w.days[t.Weekday()] && offset >= w.start && offset < w.end
+	}
+
+	// The window crosses midnight. It is either in the part that started
+	// today, or in the part that started yesterday.
+	yesterday := (t.Weekday() + 6) % 7
+	return (w.days[t.Weekday()] && offset >= w.start) || (w.days[yesterday] && offset < w.end)
+}
+
+func (w *recurringWindow) String() string { return w.raw }
@@ -0,0 +1,103 @@
+package guardrail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, s)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestParseChangeWindow_Fixed(t *testing.T) {
+	w, err := ParseChangeWindow("2023-12-01T09:00:00Z/2023-12-01T17:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, "2023-12-01T09:00:00Z/2023-12-01T17:00:00Z", w.String())
+
+	require.False(t, w.Contains(mustParseTime(t, "2023-12-01T08:59:59Z")))
+	require.True(t, w.Contains(mustParseTime(t, "2023-12-01T09:00:00Z")))
+	require.True(t, w.Contains(mustParseTime(t, "2023-12-01T11:00:00-05:00")))
+	require.False(t, w.Contains(mustParseTime(t, "2023-12-01T17:00:00Z")))
+}
+
+func TestParseChangeWindow_Recurring(t *testing.T) {
+	tests := []struct {
+		window  string
+		inside  []string
+		outside []string
+	}{
+		{
+			window:  "Mon-Fri 09:00-17:00",
+			inside:  []string{"2023-12-04T09:00:00Z", "2023-12-08T16:59:00Z"},
+			outside: []string{"2023-12-04T08:59:00Z", "2023-12-04T17:00:00Z", "2023-12-09T12:00:00Z"},
+		},
+		{
+			window:  "Mon,Wed 09:00-17:00 America/New_York",
+			inside:  []string{"2023-12-04T14:00:00Z", "2023-12-06T21:59:00Z"},
+			outside: []string{"2023-12-04T13:59:00Z", "2023-12-05T14:00:00Z"},
+		},
+		{
+			// Wraps around the end of the week
+			window:  "Fri-Mon 00:00-24:00",
+			inside:  []string{"2023-12-08T00:00:00Z", "2023-12-10T12:00:00Z", "2023-12-11T23:59:00Z"},
+			outside: []string{"2023-12-07T23:59:00Z", "2023-12-12T00:00:00Z"},
+		},
+		{
+			// Crosses midnight
+			window:  "Sat 22:00-02:00",
+			inside:  []string{"2023-12-09T22:00:00Z", "2023-12-10T01:59:00Z"},
+			outside: []string{"2023-12-09T02:00:00Z", "2023-12-10T02:00:00Z", "2023-12-10T22:00:00Z"},
+		},
+		{
+			// Daylight saving time starts (2024-03-10) and ends (2024-11-03)
+			window:  "Sun 09:00-17:00 America/New_York",
+			inside:  []string{"2024-03-10T13:00:00Z", "2024-03-10T20:59:00Z", "2024-11-03T14:00:00Z", "2024-11-03T21:59:00Z"},
+			outside: []string{"2024-03-10T12:59:00Z", "2024-03-10T21:00:00Z", "2024-11-03T13:59:00Z", "2024-11-03T22:00:00Z"},
+		},
+		{
+			window:  "* 01:00-03:00",
+			inside:  []string{"2023-12-04T01:00:00Z", "2023-12-10T02:00:00Z"},
+			outside: []string{"2023-12-04T03:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			w, err := ParseChangeWindow(tt.window)
+			require.NoError(t, err)
+			require.Equal(t, tt.window, w.String())
+
+			for _, s := range tt.inside {
+				require.True(t, w.Contains(mustParseTime(t, s)), "expected %s to be inside the window", s)
+			}
+			for _, s := range tt.outside {
+				require.False(t, w.Contains(mustParseTime(t, s)), "expected %s to be outside the window", s)
+			}
+		})
+	}
+}
+
+func TestParseChangeWindow_Invalid(t *testing.T) {
+	for _, window := range []string{
+		"",
+		"Mon-Fri",
+		"Mon-Fri 09:00",
+		"Mon-Fri 9:00-17:00",
+		"Mon-Fri 09:00-25:00",
+		"Mon-Fri 09:60-17:00",
+		"Mon-Fri 09:00-09:00",
+		"Foo 09:00-17:00",
+		"Mon-Fri 09:00-17:00 Not/AZone",
+		"Mon-Fri 09:00-17:00 UTC extra",
+		"2023-12-01/2023-12-02",
+		"2023-12-01T17:00:00Z/2023-12-01T09:00:00Z",
+	} {
+		_, err := ParseChangeWindow(window)
+		require.Error(t, err, "expected %q to be invalid", window)
+	}
+}
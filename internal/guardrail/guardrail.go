@@ -0,0 +1,179 @@
+// Package guardrail implements provider-level safety checks that restrict the
+// changes resources are allowed to plan.
+package guardrail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+)
+
+// Guardrails restrict the changes resources are allowed to make. A nil
+// *Guardrails allows all changes.
+type Guardrails struct {
+	// ReadOnly prevents all changes.
+	ReadOnly bool
+
+	// ChangeWindows are the periods during which changes are allowed. If
+	// empty, changes are allowed at any time.
+	ChangeWindows []ChangeWindow
+
+	// AllowedEnvironmentIDs are the only environments resources may reference.
+	// If nil, resources may reference any environment.
+	AllowedEnvironmentIDs []string
+
+	// NamingRules, if non-nil, are conventions the names of created or
+	// renamed objects must follow.
+	NamingRules *NamingRules
+
+	// now is overridden in tests
+	now func() time.Time
+}
+
+// Operation is a change planned for a resource.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// PlannedOperation returns the operation planned by req. ok is false if no
+// change is planned.
+func PlannedOperation(req resource.ModifyPlanRequest) (op Operation, ok bool) {
+	switch {
+	case req.State.Raw.IsNull() && req.Plan.Raw.IsNull():
+		return "", false
+	case req.State.Raw.IsNull():
+		return OperationCreate, true
+	case req.Plan.Raw.IsNull():
+		return OperationDelete, true
+	case !req.Plan.Raw.Equal(req.State.Raw):
+		return OperationUpdate, true
+	default:
+		return "", false
+	}
+}
+
+// ValidatePlannedChange adds an error diagnostic to resp if req plans to
+// create, update, or delete a resource while changes are not allowed.
+// resourceName is the human readable name of the resource type used in the
+// diagnostic (e.g. "db target").
+//
+// This should be called at the start of every resource's ModifyPlan method so
+// that disallowed changes fail during plan rather than during apply.
+func (g *Guardrails) ValidatePlannedChange(resourceName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if g == nil {
+		return
+	}
+
+	op, ok := PlannedOperation(req)
+	if !ok {
+		return
+	}
+
+	if g.ReadOnly {
+		resp.Diagnostics.AddError(
+			"BastionZero Provider Is Read-Only",
+			fmt.Sprintf("Cannot %s %s: the provider is configured with read_only = true (or BASTIONZERO_READ_ONLY is set), so no BastionZero objects may be created, updated, or deleted. "+
+				"Remove the read_only setting to make changes.", op, resourceName),
+		)
+		return
+	}
+
+	if len(g.ChangeWindows) == 0 {
+		return
+	}
+
+	now := time.Now
+	if g.now != nil {
+		now = g.now
+	}
+	t := now()
+	for _, w := range g.ChangeWindows {
+		if w.Contains(t) {
+			return
+		}
+	}
+
+	windows := make([]string, len(g.ChangeWindows))
+	for i, w := range g.ChangeWindows {
+		windows[i] = fmt.Sprintf("%q", w.String())
+	}
+	resp.Diagnostics.AddError(
+		"Outside of BastionZero Change Window",
+		fmt.Sprintf("Cannot %s %s: changes are only allowed during the provider's change_windows (%s), and the current time is %s. "+
+			"Wait for the next change window or update change_windows to make changes.", op, resourceName, strings.Join(windows, ", "), t.UTC().Format(time.RFC3339)),
+	)
+}
+
+// ValidateEnvironmentReferences adds an attribute error to resp for each
+// environment referenced by the planned value at p that is not one of the
+// AllowedEnvironmentIDs. The attribute must be a string (e.g. a target's
+// environment_id) or a set of strings (e.g. a policy's environments).
+//
+// References are only validated when the resource is created or updated so
+// that existing resources can still be refreshed and destroyed.
+func (g *Guardrails) ValidateEnvironmentReferences(ctx context.Context, p path.Path, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if g == nil || g.AllowedEnvironmentIDs == nil {
+		return
+	}
+
+	if op, ok := PlannedOperation(req); !ok || op == OperationDelete {
+		return
+	}
+
+	var value attr.Value
+	diags := req.Plan.GetAttribute(ctx, p, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		g.validateEnvironmentID(p, v, &resp.Diagnostics)
+	case types.Set:
+		if v.IsNull() || v.IsUnknown() {
+			return
+		}
+		for _, element := range v.Elements() {
+			if id, ok := element.(types.String); ok {
+				g.validateEnvironmentID(p.AtSetValue(id), id, &resp.Diagnostics)
+			}
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			p,
+			"Unexpected Environment Reference Type",
+			fmt.Sprintf("Expected a string or set of strings, got: %T. Please report this issue to the provider developers.", value),
+		)
+	}
+}
+
+func (g *Guardrails) validateEnvironmentID(p path.Path, id types.String, diags *diag.Diagnostics) {
+	// Unknown references are validated once they are known during apply
+	if id.IsNull() || id.IsUnknown() {
+		return
+	}
+	if slices.Contains(g.AllowedEnvironmentIDs, id.ValueString()) {
+		return
+	}
+
+	diags.AddAttributeError(
+		p,
+		"BastionZero Environment Not Allowed",
+		fmt.Sprintf("Environment %q is not one of the provider's allowed_environment_ids (%s). "+
+			"Check that this configuration is being applied with the intended BastionZero credentials, or add the environment to allowed_environment_ids.",
+			id.ValueString(), strings.Join(g.AllowedEnvironmentIDs, ", ")),
+	)
+}
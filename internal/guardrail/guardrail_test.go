@@ -0,0 +1,185 @@
+package guardrail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+var testObjectType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+
+func testObject(name *string) tftypes.Value {
+	if name == nil {
+		return tftypes.NewValue(testObjectType, nil)
+	}
+	return tftypes.NewValue(testObjectType, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, *name)})
+}
+
+func modifyPlanRequest(state, plan *string) resource.ModifyPlanRequest {
+	return resource.ModifyPlanRequest{
+		State: tfsdk.State{Raw: testObject(state)},
+		Plan:  tfsdk.Plan{Raw: testObject(plan)},
+	}
+}
+
+var testNamedSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{Required: true},
+	},
+}
+
+// namedModifyPlanRequest returns a request to change the name attribute from
+// state to plan. A nil name means the object does not exist.
+func namedModifyPlanRequest(t *testing.T, state, plan *string) resource.ModifyPlanRequest {
+	t.Helper()
+	return resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: testNamedSchema, Raw: testObject(state)},
+		Plan:  tfsdk.Plan{Schema: testNamedSchema, Raw: testObject(plan)},
+	}
+}
+
+func TestPlannedOperation(t *testing.T) {
+	foo, bar := "foo", "bar"
+
+	op, ok := PlannedOperation(modifyPlanRequest(nil, &foo))
+	require.True(t, ok)
+	require.Equal(t, OperationCreate, op)
+
+	op, ok = PlannedOperation(modifyPlanRequest(&foo, &bar))
+	require.True(t, ok)
+	require.Equal(t, OperationUpdate, op)
+
+	op, ok = PlannedOperation(modifyPlanRequest(&foo, nil))
+	require.True(t, ok)
+	require.Equal(t, OperationDelete, op)
+
+	_, ok = PlannedOperation(modifyPlanRequest(&foo, &foo))
+	require.False(t, ok)
+}
+
+func TestValidatePlannedChange(t *testing.T) {
+	foo, bar := "foo", "bar"
+	window, err := ParseChangeWindow("Mon-Fri 09:00-17:00")
+	require.NoError(t, err)
+	insideWindow := func() time.Time { return mustParseTime(t, "2023-12-04T12:00:00Z") }
+	outsideWindow := func() time.Time { return mustParseTime(t, "2023-12-09T12:00:00Z") }
+
+	tests := []struct {
+		name       string
+		guardrails *Guardrails
+		req        resource.ModifyPlanRequest
+		wantError  bool
+	}{
+		{name: "nil guardrails", guardrails: nil, req: modifyPlanRequest(nil, &foo)},
+		{name: "no restrictions", guardrails: &Guardrails{}, req: modifyPlanRequest(&foo, nil)},
+		{name: "read-only create", guardrails: &Guardrails{ReadOnly: true}, req: modifyPlanRequest(nil, &foo), wantError: true},
+		{name: "read-only update", guardrails: &Guardrails{ReadOnly: true}, req: modifyPlanRequest(&foo, &bar), wantError: true},
+		{name: "read-only delete", guardrails: &Guardrails{ReadOnly: true}, req: modifyPlanRequest(&foo, nil), wantError: true},
+		{name: "read-only no change", guardrails: &Guardrails{ReadOnly: true}, req: modifyPlanRequest(&foo, &foo)},
+		{name: "inside change window", guardrails: &Guardrails{ChangeWindows: []ChangeWindow{window}, now: insideWindow}, req: modifyPlanRequest(&foo, &bar)},
+		{name: "outside change window", guardrails: &Guardrails{ChangeWindows: []ChangeWindow{window}, now: outsideWindow}, req: modifyPlanRequest(&foo, &bar), wantError: true},
+		{name: "outside change window no change", guardrails: &Guardrails{ChangeWindows: []ChangeWindow{window}, now: outsideWindow}, req: modifyPlanRequest(&foo, &foo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{}
+			tt.guardrails.ValidatePlannedChange("test object", tt.req, resp)
+			require.Equal(t, tt.wantError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+var testEnvironmentSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"environment_id": schema.StringAttribute{Optional: true},
+		"environments":   schema.SetAttribute{ElementType: types.StringType, Optional: true},
+	},
+}
+
+func environmentModifyPlanRequest(t *testing.T, state, plan tftypes.Value) resource.ModifyPlanRequest {
+	t.Helper()
+	return resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: testEnvironmentSchema, Raw: state},
+		Plan:  tfsdk.Plan{Schema: testEnvironmentSchema, Raw: plan},
+	}
+}
+
+func environmentObject(t *testing.T, environmentID tftypes.Value, environments tftypes.Value) tftypes.Value {
+	t.Helper()
+	objectType := testEnvironmentSchema.Type().TerraformType(context.Background())
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"environment_id": environmentID,
+		"environments":   environments,
+	})
+}
+
+func TestValidateEnvironmentReferences(t *testing.T) {
+	setType := tftypes.Set{ElementType: tftypes.String}
+	stringSet := func(ids ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(ids))
+		for i, id := range ids {
+			elements[i] = tftypes.NewValue(tftypes.String, id)
+		}
+		return tftypes.NewValue(setType, elements)
+	}
+	nullState := tftypes.NewValue(testEnvironmentSchema.Type().TerraformType(context.Background()), nil)
+	allowed := &Guardrails{AllowedEnvironmentIDs: []string{"env-1", "env-2"}}
+
+	tests := []struct {
+		name       string
+		guardrails *Guardrails
+		req        resource.ModifyPlanRequest
+		wantErrors int
+	}{
+		{
+			name:       "no allow-list",
+			guardrails: &Guardrails{},
+			req:        environmentModifyPlanRequest(t, nullState, environmentObject(t, tftypes.NewValue(tftypes.String, "env-3"), stringSet("env-3"))),
+		},
+		{
+			name:       "allowed",
+			guardrails: allowed,
+			req:        environmentModifyPlanRequest(t, nullState, environmentObject(t, tftypes.NewValue(tftypes.String, "env-1"), stringSet("env-1", "env-2"))),
+		},
+		{
+			name:       "not allowed",
+			guardrails: allowed,
+			req:        environmentModifyPlanRequest(t, nullState, environmentObject(t, tftypes.NewValue(tftypes.String, "env-3"), stringSet("env-1", "env-3", "env-4"))),
+			wantErrors: 3,
+		},
+		{
+			name:       "unknown",
+			guardrails: allowed,
+			req:        environmentModifyPlanRequest(t, nullState, environmentObject(t, tftypes.NewValue(tftypes.String, tftypes.UnknownValue), tftypes.NewValue(setType, tftypes.UnknownValue))),
+		},
+		{
+			name:       "empty allow-list",
+			guardrails: &Guardrails{AllowedEnvironmentIDs: []string{}},
+			req:        environmentModifyPlanRequest(t, nullState, environmentObject(t, tftypes.NewValue(tftypes.String, "env-1"), tftypes.NewValue(setType, nil))),
+			wantErrors: 1,
+		},
+		{
+			name:       "delete",
+			guardrails: allowed,
+			req:        environmentModifyPlanRequest(t, environmentObject(t, tftypes.NewValue(tftypes.String, "env-3"), stringSet("env-3")), nullState),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{}
+			tt.guardrails.ValidateEnvironmentReferences(context.Background(), path.Root("environment_id"), tt.req, resp)
+			tt.guardrails.ValidateEnvironmentReferences(context.Background(), path.Root("environments"), tt.req, resp)
+			require.Equal(t, tt.wantErrors, resp.Diagnostics.ErrorsCount(), resp.Diagnostics)
+		})
+	}
+}
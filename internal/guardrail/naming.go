@@ -0,0 +1,109 @@
+package guardrail
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NamingRules are conventions the names of managed objects must follow. The
+// zero value allows any name.
+type NamingRules struct {
+	// Pattern, if non-nil, must match the name.
+	Pattern *regexp.Regexp
+
+	// RequiredPrefix, if non-empty, must prefix the name.
+	RequiredPrefix string
+
+	// MaxLength, if positive, is the maximum number of characters in the
+	// name.
+	MaxLength int
+}
+
+// Validate returns the reasons name does not follow the rules. It returns nil
+// if name follows all of the rules.
+func (n *NamingRules) Validate(name string) []string {
+	if n == nil {
+		return nil
+	}
+
+	var violations []string
+	if n.RequiredPrefix != "" && !strings.HasPrefix(name, n.RequiredPrefix) {
+		violations = append(violations, fmt.Sprintf("must start with %q", n.RequiredPrefix))
+	}
+	if n.MaxLength > 0 && utf8.RuneCountInString(name) > n.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", n.MaxLength))
+	}
+	if n.Pattern != nil && !n.Pattern.MatchString(name) {
+		violations = append(violations, fmt.Sprintf("must match the regular expression %q", n.Pattern.String()))
+	}
+
+	return violations
+}
+
+// ValidateName adds an attribute error to resp if the planned value of the
+// resource's name attribute does not follow the NamingRules.
+//
+// The name is only validated when the resource is created or its name changes
+// so that objects created before the rules were introduced can still be
+// managed.
+func (g *Guardrails) ValidateName(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if g == nil || g.NamingRules == nil {
+		return
+	}
+
+	op, ok := PlannedOperation(req)
+	if !ok || op == OperationDelete {
+		return
+	}
+
+	namePath := path.Root("name")
+
+	var name types.String
+	diags := req.Plan.GetAttribute(ctx, namePath, &name)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	// Unknown names are validated once they are known during apply
+	if name.IsNull() || name.IsUnknown() {
+		return
+	}
+
+	if op == OperationUpdate {
+		var priorName types.String
+		diags := req.State.GetAttribute(ctx, namePath, &priorName)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		if name.Equal(priorName) {
+			return
+		}
+	}
+
+	if violations := g.NamingRules.Validate(name.ValueString()); len(violations) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			namePath,
+			"Name Does Not Follow BastionZero Naming Rules",
+			fmt.Sprintf("The name %q does not follow the provider's naming_rules: it %s.", name.ValueString(), strings.Join(violations, ", and it ")),
+		)
+	}
+}
+
+// ValidateRename is like ValidateName, but only validates the name when an
+// update changes it. Use it for resources that adopt existing objects by name,
+// where the name on create was chosen by whoever registered the object rather
+// than by Terraform.
+func (g *Guardrails) ValidateRename(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if op, ok := PlannedOperation(req); !ok || op != OperationUpdate {
+		return
+	}
+	g.ValidateName(ctx, req, resp)
+}
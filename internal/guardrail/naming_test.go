@@ -0,0 +1,86 @@
+package guardrail
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingRules_Validate(t *testing.T) {
+	rules := &NamingRules{
+		Pattern:        regexp.MustCompile(`^tf-[a-z0-9-]+$`),
+		RequiredPrefix: "tf-",
+		MaxLength:      10,
+	}
+
+	require.Empty(t, rules.Validate("tf-foo"))
+	require.Empty(t, rules.Validate("tf-1234567"))
+	require.Len(t, rules.Validate("tf-12345678"), 1)
+	require.Len(t, rules.Validate("tf-Foo"), 1)
+	require.Len(t, rules.Validate("foo"), 2)
+	require.Len(t, rules.Validate("Foo-bar-baz"), 3)
+
+	// Length is measured in characters, not bytes
+	require.Empty(t, (&NamingRules{MaxLength: 3}).Validate("äöü"))
+
+	var nilRules *NamingRules
+	require.Empty(t, nilRules.Validate("anything"))
+	require.Empty(t, (&NamingRules{}).Validate("anything"))
+}
+
+func TestValidateName(t *testing.T) {
+	foo, bar, tfFoo, tfBar := "foo", "bar", "tf-foo", "tf-bar"
+	guardrails := &Guardrails{NamingRules: &NamingRules{RequiredPrefix: "tf-"}}
+
+	tests := []struct {
+		name       string
+		guardrails *Guardrails
+		req        resource.ModifyPlanRequest
+		wantError  bool
+	}{
+		{name: "no naming rules", guardrails: &Guardrails{}, req: namedModifyPlanRequest(t, nil, &foo)},
+		{name: "create", guardrails: guardrails, req: namedModifyPlanRequest(t, nil, &tfFoo)},
+		{name: "create invalid", guardrails: guardrails, req: namedModifyPlanRequest(t, nil, &foo), wantError: true},
+		{name: "rename", guardrails: guardrails, req: namedModifyPlanRequest(t, &foo, &tfFoo)},
+		{name: "rename invalid", guardrails: guardrails, req: namedModifyPlanRequest(t, &tfFoo, &bar), wantError: true},
+		{name: "existing invalid name", guardrails: guardrails, req: namedModifyPlanRequest(t, &foo, &foo)},
+		{name: "delete", guardrails: guardrails, req: namedModifyPlanRequest(t, &foo, nil)},
+		{name: "rename between valid names", guardrails: guardrails, req: namedModifyPlanRequest(t, &tfFoo, &tfBar)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{}
+			tt.guardrails.ValidateName(context.Background(), tt.req, resp)
+			require.Equal(t, tt.wantError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestValidateRename(t *testing.T) {
+	foo, bar, tfFoo := "foo", "bar", "tf-foo"
+	guardrails := &Guardrails{NamingRules: &NamingRules{RequiredPrefix: "tf-"}}
+
+	tests := []struct {
+		name      string
+		req       resource.ModifyPlanRequest
+		wantError bool
+	}{
+		{name: "adopt invalid", req: namedModifyPlanRequest(t, nil, &foo)},
+		{name: "rename", req: namedModifyPlanRequest(t, &foo, &tfFoo)},
+		{name: "rename invalid", req: namedModifyPlanRequest(t, &tfFoo, &bar), wantError: true},
+		{name: "existing invalid name", req: namedModifyPlanRequest(t, &foo, &foo)},
+		{name: "delete", req: namedModifyPlanRequest(t, &foo, nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ModifyPlanResponse{}
+			guardrails.ValidateRename(context.Background(), tt.req, resp)
+			require.Equal(t, tt.wantError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package listcache caches the results of the BastionZero API's list
+// endpoints so that data sources and resources that look up objects of the
+// same type (e.g. many bastionzero_bzero_target data sources, or many
+// bastionzero_environment_target_attachment resources) share one list request.
+//
+// A Cache is created when the provider is configured and lives as long as the
+// provider instance, i.e. for a single Terraform operation. Results are cached
+// for a short time and discarded as soon as a resource creates, updates, or
+// deletes an object of the same kind.
+package listcache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTTL is how long list results are cached by default.
+const DefaultTTL = 30 * time.Second
+
+// Kind is a kind of BastionZero object returned by a list endpoint.
+type Kind string
+
+const (
+	KindBzeroTarget                Kind = "bzero_target"
+	KindClusterTarget              Kind = "cluster_target"
+	KindDbTarget                   Kind = "db_target"
+	KindWebTarget                  Kind = "web_target"
+	KindDynamicAccessConfiguration Kind = "dynamic_access_configuration"
+	KindEnvironment                Kind = "environment"
+	KindUser                       Kind = "user"
+	KindServiceAccount             Kind = "service_account"
+	KindGroup                      Kind = "group"
+	KindTargetConnectPolicy        Kind = "target_connect_policy"
+	KindKubernetesPolicy           Kind = "kubernetes_policy"
+	KindProxyPolicy                Kind = "proxy_policy"
+	KindSessionRecordingPolicy     Kind = "session_recording_policy"
+	KindJITPolicy                  Kind = "jit_policy"
+)
+
+// Cache caches list results by kind. The zero value is not usable; create a
+// Cache with New. A nil *Cache is valid and caches nothing.
+type Cache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	group singleflight.Group
+
+	mu sync.Mutex
+	// generations is incremented every time a kind is invalidated, so that
+	// results fetched before the invalidation are neither stored nor shared
+	generations map[Kind]uint64
+	entries     map[string]entry
+}
+
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// New returns a Cache that caches results for ttl.
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:         ttl,
+		now:         time.Now,
+		generations: make(map[Kind]uint64),
+		entries:     make(map[string]entry),
+	}
+}
+
+// Invalidate discards the cached results of kinds. Requests in flight when
+// Invalidate is called are not shared with later callers.
+func (c *Cache) Invalidate(kinds ...Kind) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, kind := range kinds {
+		c.generations[kind]++
+		for k := range c.entries {
+			if entryKind(k) == kind {
+				delete(c.entries, k)
+			}
+		}
+	}
+}
+
+// List returns the objects of kind listed by fetch, using the Cache carried by
+// ctx. key distinguishes lists of the same kind fetched with different options
+// (e.g. filters), and is empty if there are none.
+//
+// If the list was fetched within the cache's TTL, and no object of kind has
+// been changed since, a copy of the cached list is returned. Otherwise, fetch
+// is called; concurrent callers for the same kind and key share one call.
+// Errors are not cached.
+//
+// If ctx does not carry a Cache, fetch is called directly.
+func List[T any](ctx context.Context, kind Kind, key string, fetch func(ctx context.Context) ([]T, error)) ([]T, error) {
+	c := FromContext(ctx)
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	generation := c.generations[kind]
+	cacheKey := fmt.Sprintf("%s/%d/%s", kind, generation, key)
+	if e, ok := c.entries[cacheKey]; ok && !isRefresh(ctx) && c.now().Before(e.expiresAt) {
+		c.mu.Unlock()
+		return slices.Clone(e.value.([]T)), nil
+	}
+	c.mu.Unlock()
+
+	ch := c.group.DoChan(cacheKey, func() (any, error) {
+		// Do not let one caller's cancellation fail the other callers that
+		// share this call
+		result, err := fetch(detachedContext{ctx})
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generations[kind] == generation {
+			c.entries[cacheKey] = entry{value: result, expiresAt: c.now().Add(c.ttl)}
+		}
+		return result, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return slices.Clone(res.Val.([]T)), nil
+	}
+}
+
+// entryKind returns the kind of the entry with key k.
+func entryKind(k string) Kind {
+	kind, _, _ := strings.Cut(k, "/")
+	return Kind(kind)
+}
+
+// detachedContext carries the values of its parent, such as its logger, but
+// is never canceled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+
+type cacheContextKey struct{}
+
+type refreshContextKey struct{}
+
+// NewContext returns a copy of ctx that carries c.
+func NewContext(ctx context.Context, c *Cache) context.Context {
+	return context.WithValue(ctx, cacheContextKey{}, c)
+}
+
+// FromContext returns the Cache carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Cache {
+	c, _ := ctx.Value(cacheContextKey{}).(*Cache)
+	return c
+}
+
+// WithRefresh returns a copy of ctx in which List ignores cached results, but
+// still shares calls in flight and caches the results it fetches. Use it when
+// retrying a lookup that failed because the cached list was missing an object.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshContextKey{}, true)
+}
+
+func isRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshContextKey{}).(bool)
+	return refresh
+}
@@ -0,0 +1,208 @@
+package listcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// counter returns a fetch function that returns a single element list of the
+// number of times it has been called.
+func counter(calls *int32) func(context.Context) ([]int32, error) {
+	return func(context.Context) ([]int32, error) {
+		return []int32{atomic.AddInt32(calls, 1)}, nil
+	}
+}
+
+func TestList_CachesResults(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+
+	for i := 0; i < 3; i++ {
+		result, err := List(ctx, KindEnvironment, "", counter(&calls))
+		require.NoError(t, err)
+		require.Equal(t, []int32{1}, result)
+	}
+	require.Equal(t, int32(1), calls)
+
+	// Different keys and kinds are cached separately
+	result, err := List(ctx, KindEnvironment, "filter", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+	result, err = List(ctx, KindBzeroTarget, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{3}, result)
+}
+
+func TestList_ReturnsCopies(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+
+	result, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	result[0] = 100
+
+	result, err = List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{1}, result)
+}
+
+func TestList_Expires(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	var calls int32
+
+	_, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	result, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+}
+
+func TestList_Invalidate(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var envCalls, targetCalls int32
+
+	_, err := List(ctx, KindEnvironment, "", counter(&envCalls))
+	require.NoError(t, err)
+	_, err = List(ctx, KindBzeroTarget, "", counter(&targetCalls))
+	require.NoError(t, err)
+
+	c.Invalidate(KindEnvironment)
+
+	result, err := List(ctx, KindEnvironment, "", counter(&envCalls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+	result, err = List(ctx, KindBzeroTarget, "", counter(&targetCalls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{1}, result)
+}
+
+func TestList_InvalidateDuringFetch(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := List(ctx, KindEnvironment, "", func(ctx context.Context) ([]int32, error) {
+			close(fetching)
+			<-release
+			return []int32{atomic.AddInt32(&calls, 1)}, nil
+		})
+		require.NoError(t, err)
+	}()
+
+	<-fetching
+	c.Invalidate(KindEnvironment)
+	close(release)
+	<-done
+
+	// The result fetched before the invalidation must not be cached
+	result, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+}
+
+func TestList_SharesConcurrentCalls(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]int32, error) {
+		<-release
+		return []int32{atomic.AddInt32(&calls, 1)}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := List(ctx, KindBzeroTarget, "", fetch)
+			require.NoError(t, err)
+			require.Equal(t, []int32{1}, result)
+		}()
+	}
+
+	// Give the callers time to join the call in flight
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), calls)
+}
+
+func TestList_DoesNotCacheErrors(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+
+	_, err := List(ctx, KindEnvironment, "", func(context.Context) ([]int32, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+
+	result, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+}
+
+func TestList_Refresh(t *testing.T) {
+	c := New(time.Minute)
+	ctx := NewContext(context.Background(), c)
+	var calls int32
+
+	_, err := List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+
+	result, err := List(WithRefresh(ctx), KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+
+	// The refreshed result is cached
+	result, err = List(ctx, KindEnvironment, "", counter(&calls))
+	require.NoError(t, err)
+	require.Equal(t, []int32{2}, result)
+}
+
+func TestList_CallerCanceled(t *testing.T) {
+	c := New(time.Minute)
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithCancel(NewContext(context.Background(), c))
+	cancel()
+	_, err := List(ctx, KindEnvironment, "", func(ctx context.Context) ([]int32, error) {
+		<-release
+		return nil, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestList_NoCache(t *testing.T) {
+	var c *Cache
+	var calls int32
+
+	for i := 0; i < 2; i++ {
+		_, err := List(context.Background(), KindEnvironment, "", counter(&calls))
+		require.NoError(t, err)
+	}
+	c.Invalidate(KindEnvironment)
+	require.Equal(t, int32(2), calls)
+}
@@ -0,0 +1,85 @@
+// Package ownership marks BastionZero objects as managed by the provider.
+package ownership
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Marker is stamped at the end of the descriptions of objects created or
+// updated by the provider so that they can be told apart from objects created
+// by other means (e.g. the web app). A nil *Marker stamps nothing.
+type Marker struct {
+	value string
+}
+
+// NewMarker returns a marker with the given value.
+func NewMarker(value string) (*Marker, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, errors.New("marker must not be empty")
+	}
+	if strings.TrimSpace(value) != value {
+		return nil, errors.New("marker must not start or end with whitespace")
+	}
+
+	return &Marker{value: value}, nil
+}
+
+// String returns the marker's value.
+func (m *Marker) String() string {
+	if m == nil {
+		return ""
+	}
+	return m.value
+}
+
+// Stamp returns description with the marker appended.
+func (m *Marker) Stamp(description string) string {
+	if m == nil {
+		return description
+	}
+	if description == "" {
+		return m.value
+	}
+	return description + " " + m.value
+}
+
+// Strip returns description without the marker appended by Stamp. It is the
+// inverse of Stamp; descriptions without the marker are returned unchanged.
+func (m *Marker) Strip(description string) string {
+	if m == nil {
+		return description
+	}
+	if description == m.value {
+		return ""
+	}
+	return strings.TrimSuffix(description, " "+m.value)
+}
+
+// IsStamped reports whether description has been stamped with the marker.
+func (m *Marker) IsStamped(description string) bool {
+	if m == nil {
+		return false
+	}
+	return description == m.value || strings.HasSuffix(description, " "+m.value)
+}
+
+// StampValue is like Stamp but for Terraform string values. Null and unknown
+// values are returned unchanged.
+func (m *Marker) StampValue(description types.String) types.String {
+	if description.IsNull() || description.IsUnknown() {
+		return description
+	}
+	return types.StringValue(m.Stamp(description.ValueString()))
+}
+
+// StripValue is like Strip but for Terraform string values. Null and unknown
+// values are returned unchanged.
+func (m *Marker) StripValue(description types.String) types.String {
+	if description.IsNull() || description.IsUnknown() {
+		return description
+	}
+	return types.StringValue(m.Strip(description.ValueString()))
+}
@@ -0,0 +1,62 @@
+package ownership
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func TestNewMarker(t *testing.T) {
+	_, err := NewMarker("[managed-by-terraform]")
+	require.NoError(t, err)
+
+	for _, value := range []string{"", " ", " [tf]", "[tf]\n"} {
+		_, err := NewMarker(value)
+		require.Error(t, err, "expected %q to be invalid", value)
+	}
+}
+
+func TestMarker_StampStrip(t *testing.T) {
+	m, err := NewMarker("[tf]")
+	require.NoError(t, err)
+
+	require.Equal(t, "[tf]", m.Stamp(""))
+	require.Equal(t, "foo [tf]", m.Stamp("foo"))
+	require.Equal(t, "", m.Strip("[tf]"))
+	require.Equal(t, "foo", m.Strip("foo [tf]"))
+	require.Equal(t, "foo", m.Strip("foo"))
+	require.Equal(t, "foo[tf]", m.Strip("foo[tf]"))
+
+	require.True(t, m.IsStamped("[tf]"))
+	require.True(t, m.IsStamped("foo [tf]"))
+	require.False(t, m.IsStamped("foo"))
+	require.False(t, m.IsStamped("foo[tf]"))
+	require.False(t, m.IsStamped("[tf] foo"))
+
+	require.Equal(t, types.StringValue("foo [tf]"), m.StampValue(types.StringValue("foo")))
+	require.Equal(t, types.StringValue("foo"), m.StripValue(types.StringValue("foo [tf]")))
+	require.True(t, m.StampValue(types.StringNull()).IsNull())
+	require.True(t, m.StripValue(types.StringUnknown()).IsUnknown())
+}
+
+func TestMarker_Nil(t *testing.T) {
+	var m *Marker
+	require.Equal(t, "foo", m.Stamp("foo"))
+	require.Equal(t, "foo [tf]", m.Strip("foo [tf]"))
+	require.False(t, m.IsStamped("foo [tf]"))
+	require.Equal(t, "", m.String())
+}
+
+func TestMarker_StripIsInverseOfStamp(t *testing.T) {
+	m, err := NewMarker("[tf]")
+	require.NoError(t, err)
+
+	rapid.Check(t, func(t *rapid.T) {
+		description := rapid.String().Draw(t, "description")
+		stamped := m.Stamp(description)
+		require.True(t, m.IsStamped(stamped))
+		require.Equal(t, description, m.Strip(stamped))
+	})
+}
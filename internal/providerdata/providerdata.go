@@ -0,0 +1,33 @@
+// Package providerdata defines the data the provider passes to its resources
+// and data sources when they are configured.
+package providerdata
+
+import (
+	"github.com/bastionzero/bastionzero-sdk-go/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/guardrail"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/listcache"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/ownership"
+)
+
+// ProviderData is the value of ResourceData and DataSourceData set by the
+// provider's Configure method.
+type ProviderData struct {
+	// Client is the configured BastionZero API client.
+	Client *bastionzero.Client
+
+	// Guardrails restrict the changes resources are allowed to plan.
+	Guardrails *guardrail.Guardrails
+
+	// OwnershipMarker is stamped in the descriptions of objects managed by
+	// the provider. It is nil if the provider is not configured with one.
+	OwnershipMarker *ownership.Marker
+
+	// AuditLog records the changes resources make. It is nil if the provider
+	// is not configured with an audit log.
+	AuditLog *auditlog.Logger
+
+	// ListCache caches the results of list requests. Resources invalidate it
+	// when they change an object.
+	ListCache *listcache.Cache
+}
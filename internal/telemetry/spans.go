@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Operation is a resource or data source operation.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationRead   Operation = "read"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// StartResourceSpan starts a span for the operation op on a resource of type
+// typeName (e.g. "bastionzero_environment"). The span must be ended with
+// EndSpan.
+func StartResourceSpan(ctx context.Context, typeName string, op Operation) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, typeName+"."+string(op), trace.WithAttributes(
+		ObjectTypeKey.String(typeName),
+		OperationKey.String(string(op)),
+	))
+}
+
+// StartDataSourceSpan starts a span for reading a data source of type
+// typeName (e.g. "bastionzero_environments"). The span must be ended with
+// EndSpan.
+func StartDataSourceSpan(ctx context.Context, typeName string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "data."+typeName+"."+string(OperationRead), trace.WithAttributes(
+		ObjectTypeKey.String(typeName),
+		OperationKey.String(string(OperationRead)),
+	))
+}
+
+// EndSpan records the ID of the object in state (if it has an "id" attribute)
+// and any error diagnostics on span, and ends it. Pointers are taken so that
+// EndSpan can be deferred before the operation has set the state or
+// diagnostics.
+func EndSpan(ctx context.Context, span trace.Span, state *tfsdk.State, diags *diag.Diagnostics) {
+	defer span.End()
+
+	if state != nil && !state.Raw.IsNull() {
+		var id types.String
+		// Not every object has an ID, so errors are ignored
+		if d := state.GetAttribute(ctx, path.Root("id"), &id); !d.HasError() && !id.IsNull() && !id.IsUnknown() {
+			span.SetAttributes(ObjectIDKey.String(id.ValueString()))
+		}
+	}
+
+	if diags != nil && diags.HasError() {
+		errs := diags.Errors()
+		span.SetStatus(codes.Error, errs[0].Summary())
+		for _, d := range errs {
+			span.AddEvent("error", trace.WithAttributes(
+				DiagnosticSummaryKey.String(d.Summary()),
+				DiagnosticDetailKey.String(d.Detail()),
+			))
+		}
+	}
+}
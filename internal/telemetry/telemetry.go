@@ -0,0 +1,168 @@
+// Package telemetry traces the provider's operations with OpenTelemetry.
+//
+// Tracing is disabled unless it is enabled with the standard OTEL_* environment
+// variables. See Init.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName identifies the provider as the source of its spans.
+	instrumentationName = "github.com/bastionzero/terraform-provider-bastionzero"
+
+	// serviceName is the default service.name of the provider's spans. It
+	// can be overridden with OTEL_SERVICE_NAME.
+	serviceName = "terraform-provider-bastionzero"
+
+	// TracesFileEnvVar is the environment variable that names the file the
+	// console exporter writes spans to. If it is not set, spans are written
+	// to stderr.
+	TracesFileEnvVar = "BASTIONZERO_OTEL_TRACES_FILE"
+)
+
+// Attributes recorded on the provider's spans.
+const (
+	ObjectTypeKey = attribute.Key("bastionzero.object_type")
+	ObjectIDKey   = attribute.Key("bastionzero.object_id")
+	OperationKey  = attribute.Key("bastionzero.operation")
+
+	DiagnosticSummaryKey = attribute.Key("diagnostic.summary")
+	DiagnosticDetailKey  = attribute.Key("diagnostic.detail")
+
+	HTTPRequestMethodKey      = attribute.Key("http.request.method")
+	HTTPResponseStatusCodeKey = attribute.Key("http.response.status_code")
+	HTTPResendCountKey        = attribute.Key("http.request.resend_count")
+	URLFullKey                = attribute.Key("url.full")
+	ServerAddressKey          = attribute.Key("server.address")
+)
+
+// Tracer returns the tracer used to create the provider's spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Init configures the global tracer provider according to the following
+// environment variables and returns a function that flushes any buffered
+// spans and shuts the tracer provider down:
+//
+//   - OTEL_SDK_DISABLED: if "true", tracing is disabled.
+//   - OTEL_TRACES_EXPORTER: "otlp" exports spans to an OTLP endpoint,
+//     "console" writes spans as JSON to the file named by
+//     BASTIONZERO_OTEL_TRACES_FILE (or stderr), and "none" (the default)
+//     disables tracing.
+//   - OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL:
+//     "http/protobuf" (the default) or "grpc".
+//
+// The OTLP exporters, sampler, batch span processor, and resource are
+// further configured by their standard OTEL_* environment variables (e.g.
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_TRACES_SAMPLER, and
+// OTEL_RESOURCE_ATTRIBUTES).
+func Init(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return noop, nil
+	}
+
+	var opts []sdktrace.TracerProviderOption
+	var closers []io.Closer
+	for _, name := range strings.Split(os.Getenv("OTEL_TRACES_EXPORTER"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "none" {
+			continue
+		}
+
+		exporter, closer, err := newExporter(ctx, name)
+		if err != nil {
+			return noop, err
+		}
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	if len(opts) == 0 {
+		return noop, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		),
+		// Environment variables take precedence over the defaults above
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create OpenTelemetry resource: %w", err)
+	}
+	opts = append(opts, sdktrace.WithResource(res))
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		for _, c := range closers {
+			err = errors.Join(err, c.Close())
+		}
+		return err
+	}, nil
+}
+
+// newExporter creates the span exporter named name. The returned closer, if
+// non-nil, must be closed after the exporter is shut down.
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, io.Closer, error) {
+	switch name {
+	case "otlp":
+		protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+		if protocol == "" {
+			protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+		}
+		switch protocol {
+		case "", "http/protobuf":
+			exporter, err := otlptracehttp.New(ctx)
+			return exporter, nil, err
+		case "grpc":
+			exporter, err := otlptracegrpc.New(ctx)
+			return exporter, nil, err
+		default:
+			return nil, nil, fmt.Errorf("unsupported OTLP protocol %q: must be one of \"http/protobuf\" or \"grpc\"", protocol)
+		}
+	case "console":
+		path := os.Getenv(TracesFileEnvVar)
+		if path == "" {
+			exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+			return exporter, nil, err
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open %s: %w", TracesFileEnvVar, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q: must be one of \"otlp\", \"console\", or \"none\"", name)
+	}
+}
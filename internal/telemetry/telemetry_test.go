@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit_Disabled(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+
+	shutdown, err := Init(context.Background(), "test")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestInit_UnsupportedExporter(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+	_, err := Init(context.Background(), "test")
+	require.ErrorContains(t, err, "unsupported OTEL_TRACES_EXPORTER")
+}
+
+func TestInit_ConsoleFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	t.Setenv(TracesFileEnvVar, path)
+
+	shutdown, err := Init(ctx, "test")
+	require.NoError(t, err)
+
+	var diags diag.Diagnostics
+	diags.AddError("Error creating environment", "boom")
+	spanCtx, span := StartResourceSpan(ctx, "bastionzero_environment", OperationCreate)
+	EndSpan(spanCtx, span, nil, &diags)
+
+	require.NoError(t, shutdown(ctx))
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"Name":"bastionzero_environment.create"`)
+	require.Contains(t, string(contents), `"Description":"Error creating environment"`)
+	require.Contains(t, string(contents), `"terraform-provider-bastionzero"`)
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/bastionzero/terraform-provider-bastionzero/bastionzero"
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/telemetry"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -44,7 +45,22 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), bastionzero.New(version), opts)
+	ctx := context.Background()
+
+	// Tracing is configured by OTEL_* environment variables and is disabled
+	// by default. A misconfiguration should not prevent the provider from
+	// running.
+	shutdownTracing, err := telemetry.Init(ctx, version)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing is disabled: %s", err)
+	}
+
+	err = providerserver.Serve(ctx, bastionzero.New(version), opts)
+
+	// Flush any buffered spans before exiting
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("[WARN] Failed to flush OpenTelemetry spans: %s", shutdownErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
@@ -0,0 +1,36 @@
+// Command verifyauditlog checks the hash chain of an audit log written by the
+// provider's audit_log_path setting.
+//
+// Usage:
+//
+//	go run ./tools/verifyauditlog <path>
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bastionzero/terraform-provider-bastionzero/internal/auditlog"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: verifyauditlog <path>")
+		os.Exit(2)
+	}
+
+	f, err := os.Open(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	count, err := auditlog.Verify(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: verification failed after %d valid entries: %s\n", os.Args[1], count, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s: OK, %d entries\n", os.Args[1], count)
+}